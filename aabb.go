@@ -0,0 +1,162 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// AABB is an axis-aligned bounding box, stored as its minimum and maximum corners.
+type AABB struct {
+	Min, Max Pt
+}
+
+// EmptyAABB returns a box that contains nothing, with Min at positive infinity and Max at negative infinity. Including
+// any point in an empty box yields a zero-size box around that point, so this is the starting point for accumulating
+// bounds incrementally.
+func EmptyAABB() AABB {
+	inf := math32.Inf(1)
+	return AABB{Pt{inf, inf, inf, 1}, Pt{-inf, -inf, -inf, 1}}
+}
+
+// NewAABB creates a box from the provided corners. min and max do not need to be ordered; each component is sorted
+// before storing.
+func NewAABB(min, max Pt) AABB {
+	return EmptyAABB().Include(min).Include(max)
+}
+
+// NewAABBFromPoints returns the smallest box containing all points in pts. If pts is empty, the result is EmptyAABB().
+func NewAABBFromPoints(pts []Pt) AABB {
+	b := EmptyAABB()
+	for _, p := range pts {
+		b = b.Include(p)
+	}
+	return b
+}
+
+// NewAABBFromPt3 returns the smallest box containing all points in pts. If pts is empty, the result is EmptyAABB().
+func NewAABBFromPt3(pts []Pt3) AABB {
+	b := EmptyAABB()
+	for _, p := range pts {
+		b = b.Include(p.Homogenize())
+	}
+	return b
+}
+
+// IsEmpty returns true if b contains no points, i.e. if Min is greater than Max on any axis.
+func (b AABB) IsEmpty() bool {
+	return b.Min[0] > b.Max[0] || b.Min[1] > b.Max[1] || b.Min[2] > b.Max[2]
+}
+
+// Center returns the center point of b.
+func (b AABB) Center() Pt {
+	return b.Min.Lerp(b.Max, 0.5)
+}
+
+// Extents returns the half-size of b along each axis, i.e. the vector from the center to the Max corner.
+func (b AABB) Extents() Vec {
+	return b.Min.VecTo(b.Max).Scale(0.5)
+}
+
+// Size returns the full size of b along each axis.
+func (b AABB) Size() Vec {
+	return b.Min.VecTo(b.Max)
+}
+
+// Include returns the smallest box containing both b and p.
+func (b AABB) Include(p Pt) AABB {
+	for i := 0; i < 3; i++ {
+		b.Min[i] = math32.Min(b.Min[i], p[i])
+		b.Max[i] = math32.Max(b.Max[i], p[i])
+	}
+	return b
+}
+
+// Expand returns b grown by margin on every side. A negative margin shrinks the box.
+func (b AABB) Expand(margin Vec) AABB {
+	for i := 0; i < 3; i++ {
+		b.Min[i] -= margin[i]
+		b.Max[i] += margin[i]
+	}
+	return b
+}
+
+// Union returns the smallest box containing both b and c.
+func (b AABB) Union(c AABB) AABB {
+	for i := 0; i < 3; i++ {
+		b.Min[i] = math32.Min(b.Min[i], c.Min[i])
+		b.Max[i] = math32.Max(b.Max[i], c.Max[i])
+	}
+	return b
+}
+
+// Intersection returns the box where b and c overlap. If they do not overlap, the result is empty and the second
+// return value is false.
+func (b AABB) Intersection(c AABB) (AABB, bool) {
+	for i := 0; i < 3; i++ {
+		b.Min[i] = math32.Max(b.Min[i], c.Min[i])
+		b.Max[i] = math32.Min(b.Max[i], c.Max[i])
+	}
+	return b, !b.IsEmpty()
+}
+
+// ContainsPt returns true if p is inside b or on its boundary.
+func (b AABB) ContainsPt(p Pt) bool {
+	return p[0] >= b.Min[0] && p[0] <= b.Max[0] &&
+		p[1] >= b.Min[1] && p[1] <= b.Max[1] &&
+		p[2] >= b.Min[2] && p[2] <= b.Max[2]
+}
+
+// Intersects returns true if b and c overlap or touch.
+func (b AABB) Intersects(c AABB) bool {
+	return b.Min[0] <= c.Max[0] && b.Max[0] >= c.Min[0] &&
+		b.Min[1] <= c.Max[1] && b.Max[1] >= c.Min[1] &&
+		b.Min[2] <= c.Max[2] && b.Max[2] >= c.Min[2]
+}
+
+// IntersectRay tests r against b using the slab method. If the ray hits the box, IntersectRay returns the distance
+// along the ray to the first intersection (zero if the ray starts inside the box) and true.
+func (b AABB) IntersectRay(r Ray) (float32, bool) {
+	tMin, tMax := float32(0), math32.Inf(1)
+	for i := 0; i < 3; i++ {
+		// Division by a zero direction component yields +/-Inf, which the comparisons below handle correctly unless
+		// the origin lies exactly on a slab boundary.
+		inv := 1 / r.Direction[i]
+		t0 := (b.Min[i] - r.Origin[i]) * inv
+		t1 := (b.Max[i] - r.Origin[i]) * inv
+		if inv < 0 {
+			t0, t1 = t1, t0
+		}
+		tMin = math32.Max(tMin, t0)
+		tMax = math32.Min(tMax, t1)
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
+
+// TransformAABB returns the smallest axis-aligned box containing b after transformation by m. m is assumed to be an
+// affine transformation. This uses Jim Arvo's method from Graphics Gems, which is cheaper than transforming all eight
+// corners of b.
+func (m Mat) TransformAABB(b AABB) AABB {
+	rval := AABB{Pt{m[3][0], m[3][1], m[3][2], 1}, Pt{m[3][0], m[3][1], m[3][2], 1}}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			e := m[j][i] * b.Min[j]
+			f := m[j][i] * b.Max[j]
+			if e < f {
+				rval.Min[i] += e
+				rval.Max[i] += f
+			} else {
+				rval.Min[i] += f
+				rval.Max[i] += e
+			}
+		}
+	}
+	return rval
+}
+
+// ClosestPt returns the point inside or on the boundary of b that is closest to p. If p is inside b, p is returned.
+func (b AABB) ClosestPt(p Pt) Pt {
+	for i := 0; i < 3; i++ {
+		p[i] = math32.Max(b.Min[i], math32.Min(b.Max[i], p[i]))
+	}
+	return p
+}
@@ -0,0 +1,100 @@
+package vkm
+
+import "testing"
+
+func TestNewAABB(t *testing.T) {
+	b := NewAABBFromPoints([]Pt{NewPt(1, 2, 3), NewPt(-1, 5, 0), NewPt(0, 0, 4)})
+	exp := AABB{NewPt(-1, 0, 0), NewPt(1, 5, 4)}
+	if b != exp {
+		t.Errorf("NewAABBFromPoints failed! Expected: %+v Actual: %+v", exp, b)
+	}
+
+	if b3 := NewAABBFromPt3([]Pt3{{1, 2, 3}, {-1, 5, 0}, {0, 0, 4}}); b3 != exp {
+		t.Errorf("NewAABBFromPt3 failed! Expected: %+v Actual: %+v", exp, b3)
+	}
+
+	if !NewAABBFromPoints(nil).IsEmpty() {
+		t.Errorf("AABB from no points was not empty!")
+	}
+
+	if c := b.Center(); !c.EqualTo(NewPt(0, 2.5, 2)) {
+		t.Errorf("Center failed! Actual: %+v", c)
+	}
+	if e := b.Extents(); e != NewVec(1, 2.5, 2) {
+		t.Errorf("Extents failed! Actual: %+v", e)
+	}
+}
+
+func TestAABBSetOperations(t *testing.T) {
+	a := NewAABB(NewPt(0, 0, 0), NewPt(2, 2, 2))
+	b := NewAABB(NewPt(1, 1, 1), NewPt(3, 3, 3))
+	c := NewAABB(NewPt(5, 5, 5), NewPt(6, 6, 6))
+
+	if u, exp := a.Union(b), NewAABB(NewPt(0, 0, 0), NewPt(3, 3, 3)); u != exp {
+		t.Errorf("Union failed! Expected: %+v Actual: %+v", exp, u)
+	}
+	if i, ok := a.Intersection(b); !ok || i != NewAABB(NewPt(1, 1, 1), NewPt(2, 2, 2)) {
+		t.Errorf("Intersection failed! Actual: %+v", i)
+	}
+	if _, ok := a.Intersection(c); ok {
+		t.Errorf("Intersection of disjoint boxes reported an overlap!")
+	}
+	if !a.Intersects(b) || a.Intersects(c) {
+		t.Errorf("Intersects failed!")
+	}
+	if !a.ContainsPt(NewPt(2, 1, 0)) || a.ContainsPt(NewPt(2.1, 1, 0)) {
+		t.Errorf("ContainsPt failed!")
+	}
+	if e, exp := a.Expand(NewVec(1, 1, 1)), NewAABB(NewPt(-1, -1, -1), NewPt(3, 3, 3)); e != exp {
+		t.Errorf("Expand failed! Expected: %+v Actual: %+v", exp, e)
+	}
+}
+
+func TestAABBIntersectRay(t *testing.T) {
+	b := NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1))
+
+	if d, ok := b.IntersectRay(NewRay(NewPt(-5, 0, 0), NewVec(1, 0, 0))); !ok || d != 4 {
+		t.Errorf("Ray along X failed! Hit: %v Distance: %v", ok, d)
+	}
+	if d, ok := b.IntersectRay(NewRay(Origin(), NewVec(0, 1, 0))); !ok || d != 0 {
+		t.Errorf("Ray from inside the box failed! Hit: %v Distance: %v", ok, d)
+	}
+	if _, ok := b.IntersectRay(NewRay(NewPt(-5, 0, 0), NewVec(-1, 0, 0))); ok {
+		t.Errorf("Ray pointing away from the box reported a hit!")
+	}
+	if _, ok := b.IntersectRay(NewRay(NewPt(-5, 2, 0), NewVec(1, 0, 0))); ok {
+		t.Errorf("Ray passing beside the box reported a hit!")
+	}
+}
+
+func TestTransformAABB(t *testing.T) {
+	b := NewAABB(NewPt(-1, -2, -3), NewPt(1, 2, 3))
+	m := Identity().Scale(NewVec(2, 1, 1)).RotateYDeg(30).RotateXDeg(-70).Translate(NewVec(5, -5, 1))
+
+	// Reference result from transforming all eight corners
+	exp := EmptyAABB()
+	for i := 0; i < 8; i++ {
+		corner := b.Min
+		for axis := 0; axis < 3; axis++ {
+			if i&(1<<axis) != 0 {
+				corner[axis] = b.Max[axis]
+			}
+		}
+		exp = exp.Include(m.MultP(corner))
+	}
+
+	res := m.TransformAABB(b)
+	if !testApproxPt(res.Min, exp.Min, 0.0001) || !testApproxPt(res.Max, exp.Max, 0.0001) {
+		t.Errorf("TransformAABB failed! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestAABBClosestPt(t *testing.T) {
+	b := NewAABB(NewPt(0, 0, 0), NewPt(2, 2, 2))
+	if res, exp := b.ClosestPt(NewPt(3, 1, -1)), NewPt(2, 1, 0); res != exp {
+		t.Errorf("ClosestPt outside the box failed! Expected: %+v Actual: %+v", exp, res)
+	}
+	if p := NewPt(1, 1.5, 0.5); b.ClosestPt(p) != p {
+		t.Errorf("ClosestPt inside the box did not return the point!")
+	}
+}
@@ -0,0 +1,277 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Capsule is the set of points within Radius of the line segment from A to B: a cylinder with hemispherical caps.
+// Capsules are commonly used as collision shapes for characters. A capsule with a negative radius is empty, and
+// contains nothing.
+type Capsule struct {
+	A, B   Pt
+	Radius float32
+}
+
+// NewCapsule creates a capsule around the segment from a to b with the provided radius.
+func NewCapsule(a, b Pt, radius float32) Capsule {
+	return Capsule{a, b, radius}
+}
+
+// NewCapsuleFromPoints fits a capsule around pts. The segment runs along the principal axis of the points (the
+// eigenvector of their covariance matrix with the largest eigenvalue) through their mean, the radius is the largest
+// distance from any point to that axis, and the segment is then trimmed as far as possible while the end caps still
+// cover every point. The result contains every point, but is not necessarily the smallest capsule that does. If pts is
+// empty, the result is an empty capsule with a radius of -1.
+func NewCapsuleFromPoints(pts []Pt) Capsule {
+	if len(pts) == 0 {
+		return Capsule{Origin(), Origin(), -1}
+	}
+
+	var mean Vec
+	for _, p := range pts {
+		mean = mean.Add(Vec(p))
+	}
+	mean = mean.Scale(1 / float32(len(pts)))
+
+	var cov Mat3
+	for _, p := range pts {
+		d := Vec(p).Sub(mean)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				cov[i][j] += d[i] * d[j]
+			}
+		}
+	}
+	values, vectors := cov.symmetricEigen()
+	major := 0
+	for i := 1; i < 3; i++ {
+		if values[i] > values[major] {
+			major = i
+		}
+	}
+
+	return fitCapsule(pts, make([]float32, len(pts)), Origin().Add(mean), vectors[major].Homogenize().Normalize())
+}
+
+// fitCapsule returns a capsule along the line through origin in direction axis (a unit vector) that contains every
+// sphere described by centers and radii.
+func fitCapsule(centers []Pt, radii []float32, origin Pt, axis Vec) Capsule {
+	// The radius must reach the sphere farthest from the axis
+	t, d := make([]float32, len(centers)), make([]float32, len(centers))
+	r := float32(0)
+	for i, c := range centers {
+		v := origin.VecTo(c)
+		t[i] = v.Dot(axis)
+		d[i] = v.Sub(axis.Scale(t[i])).Length()
+		r = math32.Max(r, d[i]+radii[i])
+	}
+
+	// Each sphere is covered while its center is within r - radius of the segment, which leaves it h units of slack
+	// along the axis beyond either end. Trim each end to the tightest constraint.
+	lo, hi := math32.Inf(1), math32.Inf(-1)
+	for i := range centers {
+		rr := r - radii[i]
+		h := math32.Sqrt(math32.Max(0, rr*rr-d[i]*d[i]))
+		lo = math32.Min(lo, t[i]+h)
+		hi = math32.Max(hi, t[i]-h)
+	}
+	if lo > hi {
+		// Every sphere is covered by a single end cap at the midpoint
+		lo = (lo + hi) / 2
+		hi = lo
+	}
+	return Capsule{origin.Add(axis.Scale(lo)), origin.Add(axis.Scale(hi)), r}
+}
+
+// IsEmpty returns true if c has a negative radius.
+func (c Capsule) IsEmpty() bool {
+	return c.Radius < 0
+}
+
+// Merge returns a capsule containing both c and o. A capsule is the convex hull of the spheres at its ends, so the
+// result is fitted around those four spheres, along the line between the two end points that are farthest apart. The
+// result is not necessarily the smallest capsule containing both.
+func (c Capsule) Merge(o Capsule) Capsule {
+	switch {
+	case o.IsEmpty():
+		return c
+	case c.IsEmpty():
+		return o
+	}
+
+	centers := []Pt{c.A, c.B, o.A, o.B}
+	radii := []float32{c.Radius, c.Radius, o.Radius, o.Radius}
+
+	a, b, dist := c.A, c.B, float32(-1)
+	for i := range centers {
+		for j := i + 1; j < len(centers); j++ {
+			if d := centers[i].VecTo(centers[j]).SquareLength(); d > dist {
+				a, b, dist = centers[i], centers[j], d
+			}
+		}
+	}
+	axis := UnitVecX()
+	if dist > 0 {
+		axis = a.VecTo(b).Normalize()
+	}
+	return fitCapsule(centers, radii, a, axis)
+}
+
+// ContainsPt returns true if p is inside c or on its surface. An empty capsule contains no points.
+func (c Capsule) ContainsPt(p Pt) bool {
+	if c.IsEmpty() {
+		return false
+	}
+	return closestPtSegment(p, c.A, c.B).VecTo(p).SquareLength() <= c.Radius*c.Radius
+}
+
+// IntersectRay returns the distance along r to the first point where it enters c (zero if r starts inside c) and true,
+// or false if r misses c. The distance is measured in units of r's direction vector. A ray never hits an empty capsule.
+func (c Capsule) IntersectRay(r Ray) (float32, bool) {
+	if c.IsEmpty() {
+		return 0, false
+	}
+	if c.ContainsPt(r.Origin) {
+		return 0, true
+	}
+
+	// The capsule is the union of the two end spheres and the cylinder between them, so the first hit is the nearest
+	// hit on any of the three.
+	t, hit := Sphere{c.A, c.Radius}.IntersectRay(r)
+	if tb, ok := (Sphere{c.B, c.Radius}).IntersectRay(r); ok && (!hit || tb < t) {
+		t, hit = tb, true
+	}
+
+	axis := c.A.VecTo(c.B)
+	oa := c.A.VecTo(r.Origin)
+	baba := axis.SquareLength()
+	bard := axis.Dot(r.Direction)
+	baoa := axis.Dot(oa)
+
+	// Infinite cylinder: |oa + td|^2 - ((baoa + t*bard)^2 / baba) = radius^2, with qb halved
+	qa := baba*r.Direction.SquareLength() - bard*bard
+	qb := baba*oa.Dot(r.Direction) - baoa*bard
+	qc := baba*oa.SquareLength() - baoa*baoa - c.Radius*c.Radius*baba
+	if disc := qb*qb - qa*qc; qa > 0.000001 && disc >= 0 {
+		tc := (-qb - math32.Sqrt(disc)) / qa
+		// Only the part of the cylinder between the end caps counts
+		if y := baoa + tc*bard; tc >= 0 && y > 0 && y < baba && (!hit || tc < t) {
+			t, hit = tc, true
+		}
+	}
+	return t, hit
+}
+
+// Intersects returns true if c and o overlap or touch. An empty capsule intersects nothing.
+func (c Capsule) Intersects(o Capsule) bool {
+	if c.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+	p, q := closestPtsSegments(c.A, c.B, o.A, o.B)
+	r := c.Radius + o.Radius
+	return p.VecTo(q).SquareLength() <= r*r
+}
+
+// IntersectsSphere returns true if c and s overlap or touch.
+func (c Capsule) IntersectsSphere(s Sphere) bool {
+	if c.IsEmpty() || s.IsEmpty() {
+		return false
+	}
+	r := c.Radius + s.Radius
+	return closestPtSegment(s.Center, c.A, c.B).VecTo(s.Center).SquareLength() <= r*r
+}
+
+// IntersectsPlane returns true if c touches or crosses pl. pl must be normalized.
+func (c Capsule) IntersectsPlane(pl Plane) bool {
+	if c.IsEmpty() {
+		return false
+	}
+	da, db := pl.SignedDistance(c.A), pl.SignedDistance(c.B)
+	return math32.Min(da, db) <= c.Radius && math32.Max(da, db) >= -c.Radius
+}
+
+// IntersectsAABB returns true if c and b overlap or touch.
+func (c Capsule) IntersectsAABB(b AABB) bool {
+	if c.IsEmpty() {
+		return false
+	}
+	// The squared distance from a point on the segment to the box is a convex function of the segment parameter, so a
+	// golden section search finds its minimum.
+	dist := func(t float32) float32 {
+		p := c.A.Lerp(c.B, t)
+		return b.ClosestPt(p).VecTo(p).SquareLength()
+	}
+	r2 := c.Radius * c.Radius
+	const g = 0.618034 // 1 / golden ratio
+	lo, hi := float32(0), float32(1)
+	x1, x2 := hi-g*(hi-lo), lo+g*(hi-lo)
+	f1, f2 := dist(x1), dist(x2)
+	for i := 0; i < 40; i++ {
+		if math32.Min(f1, f2) <= r2 {
+			return true
+		}
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - g*(hi-lo)
+			f1 = dist(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + g*(hi-lo)
+			f2 = dist(x2)
+		}
+	}
+	return math32.Min(dist(lo), dist(hi)) <= r2
+}
+
+// TransformCapsule returns a capsule containing c after transformation by m, which is assumed to be affine. As with
+// [Mat.TransformSphere], the radius is scaled by the largest scale factor in m, so the result is exact for rigid
+// transforms and uniform scales, and a bounding capsule otherwise. An empty capsule is returned unchanged.
+func (m Mat) TransformCapsule(c Capsule) Capsule {
+	if c.IsEmpty() {
+		return c
+	}
+	return Capsule{m.MultP(c.A), m.MultP(c.B), c.Radius * m.maxScale()}
+}
+
+// closestPtSegment returns the point on the segment from a to b that is closest to p.
+func closestPtSegment(p, a, b Pt) Pt {
+	ab := a.VecTo(b)
+	l := ab.SquareLength()
+	if l == 0 {
+		return a
+	}
+	t := clamp(a.VecTo(p).Dot(ab)/l, 0, 1)
+	return a.Add(ab.Scale(t))
+}
+
+// closestPtsSegments returns the closest pair of points between the segments p1-q1 and p2-q2. This follows Christer
+// Ericson's Real-Time Collision Detection, section 5.1.9.
+func closestPtsSegments(p1, q1, p2, q2 Pt) (Pt, Pt) {
+	d1, d2 := p1.VecTo(q1), p2.VecTo(q2)
+	r := p2.VecTo(p1)
+	a, e, f := d1.SquareLength(), d2.SquareLength(), d2.Dot(r)
+
+	const eps = 0.000001
+	var s, t float32
+	switch {
+	case a <= eps && e <= eps:
+		return p1, p2
+	case a <= eps:
+		t = clamp(f/e, 0, 1)
+	default:
+		c := d1.Dot(r)
+		if e <= eps {
+			s = clamp(-c/a, 0, 1)
+		} else {
+			b := d1.Dot(d2)
+			if denom := a*e - b*b; denom != 0 {
+				s = clamp((b*f-c*e)/denom, 0, 1)
+			}
+			t = (b*s + f) / e
+			if t < 0 {
+				t, s = 0, clamp(-c/a, 0, 1)
+			} else if t > 1 {
+				t, s = 1, clamp((b-c)/a, 0, 1)
+			}
+		}
+	}
+	return p1.Add(d1.Scale(s)), p2.Add(d2.Scale(t))
+}
@@ -0,0 +1,175 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCapsuleIntersectRay(t *testing.T) {
+	c := NewCapsule(NewPt(0, 0, 0), NewPt(0, 4, 0), 1)
+
+	// Side of the cylinder
+	if d, ok := c.IntersectRay(NewRay(NewPt(-5, 2, 0), UnitVecX())); !ok || math32.Abs(d-4) > 0.00001 {
+		t.Errorf("Ray into the cylinder failed! Expected: 4, true Actual: %v, %v", d, ok)
+	}
+	// Bottom cap, parallel to the axis
+	if d, ok := c.IntersectRay(NewRay(NewPt(0, -5, 0), UnitVecY())); !ok || math32.Abs(d-4) > 0.00001 {
+		t.Errorf("Ray into the bottom cap failed! Expected: 4, true Actual: %v, %v", d, ok)
+	}
+	// Top cap, from above
+	if d, ok := c.IntersectRay(NewRay(NewPt(0, 10, 0), UnitVecY().Invert())); !ok || math32.Abs(d-5) > 0.00001 {
+		t.Errorf("Ray into the top cap failed! Expected: 5, true Actual: %v, %v", d, ok)
+	}
+	if _, ok := c.IntersectRay(NewRay(NewPt(-5, 2, 1.5), UnitVecX())); ok {
+		t.Errorf("Ray passing beside the capsule hit!")
+	}
+	if _, ok := c.IntersectRay(NewRay(NewPt(-5, 2, 0), UnitVecX().Invert())); ok {
+		t.Errorf("Ray pointing away from the capsule hit!")
+	}
+	if d, ok := c.IntersectRay(NewRay(NewPt(0, 3, 0.5), UnitVecX())); !ok || d != 0 {
+		t.Errorf("Ray starting inside the capsule failed! Expected: 0, true Actual: %v, %v", d, ok)
+	}
+}
+
+func TestCapsuleIntersections(t *testing.T) {
+	c := NewCapsule(NewPt(0, 0, 0), NewPt(0, 4, 0), 1)
+
+	if !c.Intersects(NewCapsule(NewPt(-3, 2, 1.5), NewPt(3, 2, 1.5), 0.6)) {
+		t.Errorf("Crossing capsules did not intersect!")
+	}
+	if c.Intersects(NewCapsule(NewPt(-3, 2, 1.5), NewPt(3, 2, 1.5), 0.4)) {
+		t.Errorf("Separate capsules intersected!")
+	}
+	if !c.Intersects(NewCapsule(NewPt(0, 5.5, 0), NewPt(0, 8, 0), 0.5)) {
+		t.Errorf("Capsules touching end to end did not intersect!")
+	}
+
+	if !c.IntersectsSphere(NewSphere(NewPt(1.5, 3, 0), 0.6)) || c.IntersectsSphere(NewSphere(NewPt(0, -2, 0), 0.9)) {
+		t.Errorf("Capsule-sphere intersection failed!")
+	}
+
+	if !c.IntersectsPlane(NewPlane(NewPt(0, 4.5, 0), UnitVecY())) || c.IntersectsPlane(NewPlane(NewPt(0, -1.5, 0), UnitVecY())) {
+		t.Errorf("Capsule-plane intersection failed!")
+	}
+
+	// A box near the middle of the side, and one near (but not touching) the rounded end
+	if !c.IntersectsAABB(NewAABB(NewPt(0.9, 1, -1), NewPt(2, 2, 1))) {
+		t.Errorf("Box touching the capsule's side did not intersect!")
+	}
+	if c.IntersectsAABB(NewAABB(NewPt(0.8, 4.8, -1), NewPt(2, 6, 1))) {
+		t.Errorf("Box beyond the capsule's rounded end intersected!")
+	}
+}
+
+func TestTransformCapsule(t *testing.T) {
+	c := NewCapsule(NewPt(0, 0, 0), NewPt(0, 4, 0), 1)
+	m := NewMatScale(NewVec(2, 1, 1)).Translate(NewVec(1, 1, 1))
+
+	res := m.TransformCapsule(c)
+	exp := NewCapsule(NewPt(1, 1, 1), NewPt(1, 5, 1), 2)
+	if !res.A.EqualTo(exp.A) || !res.B.EqualTo(exp.B) || math32.Abs(res.Radius-exp.Radius) > 0.0001 {
+		t.Errorf("TransformCapsule failed! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestNewCapsuleFromPoints(t *testing.T) {
+	// Points on a cylinder of radius 0.5 around a diagonal axis, from -3 to 3 along it
+	m := NewMatRotateZDeg(35).RotateYDeg(-20).Translate(NewVec(2, -1, 4))
+	var pts []Pt
+	for x := float32(-3); x <= 3; x += 0.5 {
+		for i := 0; i < 8; i++ {
+			a := float32(i) * math32.Pi / 4
+			pts = append(pts, m.MultP(NewPt(x, 0.5*math32.Cos(a), 0.5*math32.Sin(a))))
+		}
+	}
+
+	c := NewCapsuleFromPoints(pts)
+	if math32.Abs(c.Radius-0.5) > 0.0001 {
+		t.Errorf("Fitted capsule radius failed! Expected: 0.5 Actual: %v", c.Radius)
+	}
+	axis := m.MultV(UnitVecX())
+	if d := math32.Abs(c.A.VecTo(c.B).Normalize().Dot(axis)); math32.Abs(d-1) > 0.0001 {
+		t.Errorf("Fitted capsule was not along the points' axis! Actual: %+v", c)
+	}
+	// The end caps cover the rims at +/-3, so the segment is a little shorter than the cylinder
+	if l := c.A.VecTo(c.B).Length(); l >= 6 || l < 5 {
+		t.Errorf("Fitted capsule segment length was not trimmed to the points! Actual: %v", l)
+	}
+
+	loose := c
+	loose.Radius += 0.0001
+	for _, p := range pts {
+		if !loose.ContainsPt(p) {
+			t.Errorf("Fitted capsule %+v did not contain %+v!", c, p)
+		}
+	}
+
+	if e := NewCapsuleFromPoints(nil); !e.IsEmpty() {
+		t.Errorf("Capsule from no points was not empty! Actual: %+v", e)
+	}
+	p := NewPt(1, 2, 3)
+	if s := NewCapsuleFromPoints([]Pt{p}); !s.A.EqualTo(p) || !s.B.EqualTo(p) || s.Radius != 0 {
+		t.Errorf("Capsule from a single point failed! Actual: %+v", s)
+	}
+}
+
+func TestCapsuleMerge(t *testing.T) {
+	a := NewCapsule(NewPt(0, 0, 0), NewPt(0, 4, 0), 1)
+	b := NewCapsule(NewPt(3, 0, 1), NewPt(5, 1, 1), 0.5)
+	m := a.Merge(b)
+	m.Radius += 0.0001
+
+	// Points on the surface of both inputs must be inside the merged capsule
+	for _, c := range []Capsule{a, b} {
+		for _, end := range []Pt{c.A, c.B, c.A.Lerp(c.B, 0.5)} {
+			for _, d := range []Vec{UnitVecX(), UnitVecY(), UnitVecZ(), NewVec(1, 1, 1).Normalize()} {
+				for _, s := range []float32{c.Radius, -c.Radius} {
+					if p := end.Add(d.Scale(s)); !m.ContainsPt(p) {
+						t.Errorf("Merged capsule %+v did not contain %+v from %+v!", m, p, c)
+					}
+				}
+			}
+		}
+	}
+
+	if res := a.Merge(NewCapsuleFromPoints(nil)); res != a {
+		t.Errorf("Merging an empty capsule changed the result! Expected: %+v Actual: %+v", a, res)
+	}
+	if res := NewCapsuleFromPoints(nil).Merge(a); res != a {
+		t.Errorf("Merging into an empty capsule failed! Expected: %+v Actual: %+v", a, res)
+	}
+
+	// Merging a contained capsule must not grow the radius
+	inner := NewCapsule(NewPt(0, 1, 0), NewPt(0, 3, 0), 0.5)
+	if res := a.Merge(inner); math32.Abs(res.Radius-a.Radius) > 0.0001 {
+		t.Errorf("Merging a contained capsule grew the radius! Expected: %v Actual: %v", a.Radius, res.Radius)
+	}
+}
+
+func TestEmptyCapsule(t *testing.T) {
+	e := NewCapsuleFromPoints(nil)
+	c := NewCapsule(NewPt(-1, 0, 0), NewPt(1, 0, 0), 2)
+
+	if e.ContainsPt(Origin()) || e.ContainsPt(NewPt(0.5, 0, 0)) {
+		t.Errorf("Empty capsule contained a point!")
+	}
+	if _, ok := e.IntersectRay(NewRay(NewPt(0.5, 0, 0), UnitVecX())); ok {
+		t.Errorf("Ray starting near an empty capsule hit it!")
+	}
+	if e.Intersects(c) || c.Intersects(e) {
+		t.Errorf("Empty capsule intersected another capsule!")
+	}
+	if e.IntersectsSphere(NewSphere(Origin(), 2)) || c.IntersectsSphere(NewSphereFromPoints(nil)) {
+		t.Errorf("Capsule-sphere intersection with an empty shape succeeded!")
+	}
+	if e.IntersectsPlane(NewPlane(Origin(), UnitVecZ())) {
+		t.Errorf("Empty capsule intersected a plane!")
+	}
+	if e.IntersectsAABB(NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1))) {
+		t.Errorf("Empty capsule intersected a box!")
+	}
+	if res := NewMatScale(NewVec(3, 3, 3)).Translate(NewVec(1, 2, 3)).TransformCapsule(e); !res.IsEmpty() {
+		t.Errorf("Transformed empty capsule was not empty! Actual: %+v", res)
+	}
+}
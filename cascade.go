@@ -0,0 +1,99 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// ShadowCascade is one slice of a cascaded shadow map, as returned by [ShadowCascades].
+type ShadowCascade struct {
+	// Near and Far are the distances from the camera, along the view direction, covered by this cascade. Pass Far to
+	// the shader to select the cascade for each fragment.
+	Near, Far float32
+	// ViewProj is the light's combined view and orthographic projection matrix for this cascade.
+	ViewProj Mat
+}
+
+// CascadeSplits divides the view distance between near and far into count cascades, returning the far distance of each
+// cascade; the last value is always far. lambda blends between a uniform split (0) and a logarithmic split (1). The
+// logarithmic split matches the way perspective projection distributes detail, but gives very thin near cascades, so a
+// value around 0.5 to 0.9 is typical. If count is zero or negative, the result is nil.
+func CascadeSplits(near, far float32, count int, lambda float32) []float32 {
+	if count <= 0 {
+		return nil
+	}
+	rval := make([]float32, count)
+	for i := range rval {
+		p := float32(i+1) / float32(count)
+		log := near * math32.Pow(far/near, p)
+		uni := near + (far-near)*p
+		rval[i] = lambda*log + (1-lambda)*uni
+	}
+	rval[count-1] = far
+	return rval
+}
+
+// ShadowCascades splits the camera frustum into count cascades using [CascadeSplits], and fits an orthographic light
+// view-projection matrix around each. view is the camera's view matrix, and fov, aspect, near and far are the
+// parameters passed to [Perspective] for the camera. lightDir is the direction the light shines in, and resolution is
+// the width and height of each (square) shadow map in texels.
+//
+// Each cascade is fitted to a bounding sphere around its slice of the frustum, so its size does not change as the
+// camera rotates, and the light matrix is snapped to whole shadow map texels, so shadow edges do not shimmer as the
+// camera moves. Shadow casters outside the bounding sphere along the light direction are clipped by the light's near
+// and far planes; enable depthClampEnable in the shadow pass pipeline to keep them.
+//
+// If count is zero or negative, the result is nil.
+func ShadowCascades(view Mat, fov, aspect, near, far float32, lightDir Vec, count int, lambda float32, resolution int) []ShadowCascade {
+	if count <= 0 {
+		return nil
+	}
+
+	invView := view.InverseAffine()
+	tanY := math32.Tan(fov / 2)
+	tanX := tanY * aspect
+	l := lightDir.Normalize()
+
+	up := UnitVecY()
+	if math32.Abs(l.Dot(up)) > 0.99 {
+		up = UnitVecZ()
+	}
+
+	splits := CascadeSplits(near, far, count, lambda)
+	rval := make([]ShadowCascade, count)
+	dNear := near
+
+	for i, dFar := range splits {
+		// Corners of this slice of the frustum, in world space
+		var corners [8]Pt
+		for j, d := range []float32{dNear, dFar} {
+			corners[j*4+0] = invView.MultP(NewPt(-d*tanX, -d*tanY, -d))
+			corners[j*4+1] = invView.MultP(NewPt(d*tanX, -d*tanY, -d))
+			corners[j*4+2] = invView.MultP(NewPt(-d*tanX, d*tanY, -d))
+			corners[j*4+3] = invView.MultP(NewPt(d*tanX, d*tanY, -d))
+		}
+
+		center := Origin()
+		for _, c := range corners {
+			center = center.Add(Origin().VecTo(c).Scale(1.0 / 8))
+		}
+		var radius float32
+		for _, c := range corners {
+			radius = math32.Max(radius, center.VecTo(c).Length())
+		}
+		// Quantize the radius so floating point noise does not change the projection from frame to frame
+		radius = math32.Ceil(radius*16) / 16
+
+		lightView := Camera(center.Add(l.Scale(-radius)), l, up)
+		proj := OrthoProjection(2*radius, 2*radius, 0, 2*radius)
+		vp := proj.MultM(lightView)
+
+		// Snap to texel increments by moving the projection so that the world origin lands on a texel corner
+		half := float32(resolution) / 2
+		o := vp.MultP(Origin())
+		proj[3][0] += (math32.Round(o[0]*half) - o[0]*half) / half
+		proj[3][1] += (math32.Round(o[1]*half) - o[1]*half) / half
+
+		rval[i] = ShadowCascade{dNear, dFar, proj.MultM(lightView)}
+		dNear = dFar
+	}
+
+	return rval
+}
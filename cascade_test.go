@@ -0,0 +1,86 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCascadeSplits(t *testing.T) {
+	uni := CascadeSplits(1, 101, 4, 0)
+	for i, exp := range []float32{26, 51, 76, 101} {
+		if math32.Abs(uni[i]-exp) > 0.0001 {
+			t.Errorf("Uniform split %d failed! Expected: %v Actual: %v", i, exp, uni[i])
+		}
+	}
+
+	log := CascadeSplits(1, 1000, 3, 1)
+	for i, exp := range []float32{10, 100, 1000} {
+		if math32.Abs(log[i]-exp) > 0.001 {
+			t.Errorf("Logarithmic split %d failed! Expected: %v Actual: %v", i, exp, log[i])
+		}
+	}
+
+	blend := CascadeSplits(1, 1000, 3, 0.5)
+	for i := range blend {
+		if blend[i] < log[i] || blend[i] > CascadeSplits(1, 1000, 3, 0)[i] {
+			t.Errorf("Blended split %d is not between the logarithmic and uniform splits! Actual: %v", i, blend[i])
+		}
+	}
+
+	for _, count := range []int{0, -1} {
+		if s := CascadeSplits(1, 100, count, 0.5); s != nil {
+			t.Errorf("CascadeSplits with a count of %d was not nil! Actual: %v", count, s)
+		}
+		if c := ShadowCascades(Identity(), 1, 1, 1, 100, NewVec(0, -1, 0), count, 0.5, 1024); c != nil {
+			t.Errorf("ShadowCascades with a count of %d was not nil! Actual: %v", count, c)
+		}
+	}
+}
+
+func TestShadowCascades(t *testing.T) {
+	fov, aspect, near, far := float32(1), float32(1.5), float32(0.5), float32(200)
+	view := LookAt(NewPt(10, 5, 10), NewPt(0, 0, 0), NewVec(0, 1, 0))
+	invView := view.InverseAffine()
+	lightDir := NewVec(-1, -2, -0.5)
+	const res = 2048
+
+	cascades := ShadowCascades(view, fov, aspect, near, far, lightDir, 4, 0.75, res)
+	if len(cascades) != 4 {
+		t.Fatalf("Expected 4 cascades, found %d", len(cascades))
+	}
+	if cascades[0].Near != near || cascades[3].Far != far {
+		t.Errorf("Cascades do not cover the camera frustum! First: %+v Last: %+v", cascades[0], cascades[3])
+	}
+
+	for i, c := range cascades {
+		// Every corner of the cascade's slice of the camera frustum must be inside the light's clip volume
+		for _, d := range []float32{c.Near, c.Far} {
+			h := d * math32.Tan(fov/2)
+			for _, corner := range []Pt{
+				NewPt(-h*aspect, -h, -d), NewPt(h*aspect, -h, -d), NewPt(-h*aspect, h, -d), NewPt(h*aspect, h, -d),
+			} {
+				if r := c.ViewProj.MultP(invView.MultP(corner)); testClipped(r) {
+					t.Errorf("Cascade %d does not contain frustum corner %+v! Clip position: %+v", i, corner, r)
+				}
+			}
+		}
+
+		// The world origin must land on a texel corner
+		o := c.ViewProj.MultP(Origin())
+		for _, v := range []float32{o[0] * res / 2, o[1] * res / 2} {
+			if math32.Abs(v-math32.Round(v)) > 0.01 {
+				t.Errorf("Cascade %d is not snapped to texels! Origin in texels: %+v", i, o)
+			}
+		}
+	}
+
+	// Light direction is along the view direction of each cascade
+	for i, c := range cascades {
+		a := c.ViewProj.MultP(Origin())
+		b := c.ViewProj.MultP(Origin().Add(lightDir))
+		if math32.Abs(a[0]-b[0]) > 0.0001 || math32.Abs(a[1]-b[1]) > 0.0001 || b[2] <= a[2] {
+			t.Errorf("Cascade %d does not look along the light direction! %+v -> %+v", i, a, b)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// ClipSpace describes the conventions a graphics API uses for its view and clip spaces. The package-level projection
+// and view functions, such as [Perspective] and [Camera], always target Vulkan. The methods on ClipSpace build the
+// equivalent matricies for any API, so that one code path can drive several backends:
+//
+//	cs := vkm.ClipSpaceOpenGL
+//	viewProj := cs.Perspective(fov, aspect, near, far).MultM(cs.LookAt(eye, focus, up))
+//
+// ClipSpaceVulkan produces the same matricies as the package-level functions.
+type ClipSpace struct {
+	// DepthNegativeOneToOne selects a clip space depth range of [-1..1] (OpenGL). If false, depth is in the range
+	// [0..1] (Vulkan, Direct3D and Metal).
+	DepthNegativeOneToOne bool
+
+	// YDown indicates that positive Y in clip space points down the screen (Vulkan). If false, positive Y points up
+	// (OpenGL, Direct3D and Metal). As with [Camera], the flip is applied by the view matrix, so view space
+	// coordinates from a YDown view matrix also have Y pointing down the screen.
+	YDown bool
+
+	// LeftHanded selects left-handed world and view spaces, where the camera looks down the positive Z axis
+	// (traditionally Direct3D and Metal). If false, the camera looks down the negative Z axis (OpenGL and Vulkan).
+	// Note that the same world space data will appear mirrored between the two. If your world is right-handed and you
+	// are targeting Direct3D or Metal, use the zero value ClipSpace{} instead of ClipSpaceDirect3D.
+	LeftHanded bool
+}
+
+var (
+	// ClipSpaceVulkan is Vulkan's clip space, as used by all of the package-level projection functions.
+	ClipSpaceVulkan = ClipSpace{YDown: true}
+	// ClipSpaceOpenGL is OpenGL's default clip space.
+	ClipSpaceOpenGL = ClipSpace{DepthNegativeOneToOne: true}
+	// ClipSpaceDirect3D is Direct3D's clip space, with a traditional left-handed view.
+	ClipSpaceDirect3D = ClipSpace{LeftHanded: true}
+	// ClipSpaceMetal is Metal's clip space, with a left-handed view.
+	ClipSpaceMetal = ClipSpace{LeftHanded: true}
+)
+
+// handed converts a right-handed projection matrix into one for cs's handedness. A left-handed projection is the
+// right-handed one with view space Z negated.
+func (cs ClipSpace) handed(m Mat) Mat {
+	if cs.LeftHanded {
+		m[2] = m[2].Scale(-1)
+	}
+	return m
+}
+
+// perspectiveDepth returns the terms of a right-handed perspective projection that compute clip space z from view space
+// z and w, mapping the near and far planes to the ends of cs's depth range.
+func (cs ClipSpace) perspectiveDepth(near, far float32) (scale, offset float32) {
+	if cs.DepthNegativeOneToOne {
+		return (far + near) / (near - far), 2 * far * near / (near - far)
+	}
+	return far / (near - far), far * near / (near - far)
+}
+
+// Perspective generates a perspective projection matrix for cs. Parameters have the same meaning as in the
+// package-level [Perspective].
+func (cs ClipSpace) Perspective(fov, aspect, near, far float32) Mat {
+	f := 1 / math32.Tan(fov/2)
+	zs, zo := cs.perspectiveDepth(near, far)
+	return cs.handed(Mat{
+		{f / aspect, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, zs, -1},
+		{0, 0, zo, 0},
+	})
+}
+
+// PerspectiveDeg generates a perspective projection matrix for cs. This version accepts the FOV angle in degrees.
+func (cs ClipSpace) PerspectiveDeg(fovDeg, aspect, near, far float32) Mat {
+	return cs.Perspective(2.0*math32.Pi*fovDeg/360.0, aspect, near, far)
+}
+
+// PerspectiveOffCenter generates an asymmetric perspective projection matrix for cs. left, right, bottom and top are
+// the view space coordinates of the edges of the screen on the near plane, so, as with the package-level
+// [PerspectiveOffCenter], top is normally less than bottom when cs.YDown is set, and greater otherwise.
+func (cs ClipSpace) PerspectiveOffCenter(left, right, bottom, top, near, far float32) Mat {
+	dy := top - bottom
+	if cs.YDown {
+		dy = -dy
+	}
+	zs, zo := cs.perspectiveDepth(near, far)
+	return cs.handed(Mat{
+		{2 * near / (right - left), 0, 0, 0},
+		{0, 2 * near / dy, 0, 0},
+		{(right + left) / (right - left), (bottom + top) / dy, zs, -1},
+		{0, 0, zo, 0},
+	})
+}
+
+// OrthoProjection generates an orthographic projection matrix for cs, centered on the view axis. width and height are
+// the size of the view volume, and near and far are the distances to the near and far planes.
+func (cs ClipSpace) OrthoProjection(width, height, near, far float32) Mat {
+	top := height / 2
+	if cs.YDown {
+		top = -top
+	}
+	return cs.OrthoProjectionOffCenter(-width/2, width/2, -top, top, near, far)
+}
+
+// OrthoProjectionOffCenter generates an orthographic projection matrix for cs, mapping the view space box bounded by
+// left, right, bottom, top, and the near and far distances in front of the camera, to clip space. See
+// [ClipSpace.PerspectiveOffCenter] for the meaning of top and bottom.
+func (cs ClipSpace) OrthoProjectionOffCenter(left, right, bottom, top, near, far float32) Mat {
+	dy := top - bottom
+	if cs.YDown {
+		dy = -dy
+	}
+	zs, zo := -1/(far-near), -near/(far-near)
+	if cs.DepthNegativeOneToOne {
+		zs, zo = -2/(far-near), -(far+near)/(far-near)
+	}
+	return cs.handed(Mat{
+		{2 / (right - left), 0, 0, 0},
+		{0, 2 / dy, 0, 0},
+		{0, 0, zs, 0},
+		{-(right + left) / (right - left), -(bottom + top) / dy, zo, 1},
+	})
+}
+
+// LookAt creates a view matrix for cs from the provided eye and focus points, and an up vector. See the package-level
+// [LookAt].
+func (cs ClipSpace) LookAt(eye, focus Pt, up Vec) Mat {
+	return cs.Camera(eye, eye.VecTo(focus), up)
+}
+
+// Camera creates a view matrix for cs from the provided eye location, pointed along the direction vector. See the
+// package-level [Camera].
+func (cs ClipSpace) Camera(eye Pt, look Vec, up Vec) Mat {
+	f := look.Normalize()
+
+	// s, u and z are the rows of the rotation: the right, up and (view space) Z axes of the camera
+	var s, u, z Vec
+	if cs.LeftHanded {
+		s = up.Cross(f).Normalize()
+		u = f.Cross(s)
+		z = f
+	} else {
+		s = f.Cross(up).Normalize()
+		u = s.Cross(f)
+		z = f.Invert()
+	}
+	if cs.YDown {
+		u = u.Invert()
+	}
+
+	e := Origin().VecTo(eye)
+	return Mat{
+		{s[0], u[0], z[0], 0},
+		{s[1], u[1], z[1], 0},
+		{s[2], u[2], z[2], 0},
+		{-s.Dot(e), -u.Dot(e), -z.Dot(e), 1},
+	}
+}
+
+// NewMatClipSpaceConversion generates a matrix that converts clip space coordinates produced for the from convention
+// into the equivalent coordinates for the to convention, by remapping the depth range and flipping Y as needed. Apply
+// it to a complete view-projection matrix:
+//
+//	glViewProj := NewMatClipSpaceConversion(ClipSpaceVulkan, ClipSpaceOpenGL).MultM(vkViewProj)
+//
+// Handedness is a property of view space, not clip space, so it does not affect the conversion.
+func NewMatClipSpaceConversion(from, to ClipSpace) Mat {
+	rval := Identity()
+	if from.YDown != to.YDown {
+		rval[1][1] = -1
+	}
+	switch {
+	case from.DepthNegativeOneToOne && !to.DepthNegativeOneToOne:
+		// z' = (z + w) / 2
+		rval[2][2] = 0.5
+		rval[3][2] = 0.5
+	case !from.DepthNegativeOneToOne && to.DepthNegativeOneToOne:
+		// z' = 2z - w
+		rval[2][2] = 2
+		rval[3][2] = -1
+	}
+	return rval
+}
@@ -0,0 +1,85 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestClipSpaceVulkan(t *testing.T) {
+	cs := ClipSpaceVulkan
+	if res, exp := cs.Perspective(1, 1.5, 0.1, 100), Perspective(1, 1.5, 0.1, 100); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Vulkan Perspective did not match the package-level function! Expected: %+v Actual: %+v", exp, res)
+	}
+	if res, exp := cs.PerspectiveOffCenter(-1, 3, 2, -0.5, 1, 10), PerspectiveOffCenter(-1, 3, 2, -0.5, 1, 10); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Vulkan PerspectiveOffCenter did not match the package-level function! Expected: %+v Actual: %+v", exp, res)
+	}
+	if res, exp := cs.OrthoProjectionOffCenter(-1, 3, 2, -0.5, 1, 10), OrthoProjectionOffCenter(-1, 3, 2, -0.5, 1, 10); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Vulkan OrthoProjectionOffCenter did not match the package-level function! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	eye, focus, up := NewPt(3, 4, 5), NewPt(0, 1, 0), NewVec(0, 1, 0)
+	if res, exp := cs.LookAt(eye, focus, up), LookAt(eye, focus, up); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Vulkan LookAt did not match the package-level function! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestClipSpaceConventions(t *testing.T) {
+	eye, focus, up := NewPt(0, 0, 10), Origin(), NewVec(0, 1, 0)
+
+	for _, tc := range []struct {
+		name                string
+		cs                  ClipSpace
+		nearDepth, topClipY float32
+	}{
+		{"Vulkan", ClipSpaceVulkan, 0, -1},
+		{"OpenGL", ClipSpaceOpenGL, -1, 1},
+		{"Direct3D", ClipSpaceDirect3D, 0, 1},
+	} {
+		view := tc.cs.LookAt(eye, focus, up)
+		for _, proj := range []Mat{
+			tc.cs.PerspectiveDeg(90, 1, 1, 20),
+			tc.cs.OrthoProjection(2, 2, 1, 20),
+		} {
+			vp := proj.MultM(view)
+
+			// The world space point one unit in front of the eye and one unit up is the top center of the near plane
+			top := vp.MultP(NewPt(0, 1, 9)).Homogenize()
+			if !top.EqualTo(NewPt(0, tc.topClipY, tc.nearDepth)) {
+				t.Errorf("%s: top of near plane mapped incorrectly! Expected: %+v Actual: %+v", tc.name, NewPt(0, tc.topClipY, tc.nearDepth), top)
+			}
+
+			far := vp.MultP(NewPt(0, 0, -10)).Homogenize()
+			if math32.Abs(far[2]-1) > 0.00001 {
+				t.Errorf("%s: far plane was not mapped to depth 1! Actual: %+v", tc.name, far)
+			}
+
+			// Looking down -Z with Y up, world +X is to the right in a right-handed world and to the left in a
+			// left-handed world
+			right := vp.MultP(NewPt(0.5, 0, 9)).Homogenize()
+			if (right[0] > 0) == tc.cs.LeftHanded {
+				t.Errorf("%s: world +X mapped to the wrong side of the screen! Actual: %+v", tc.name, right)
+			}
+		}
+	}
+}
+
+func TestNewMatClipSpaceConversion(t *testing.T) {
+	eye, focus, up := NewPt(3, 4, 5), NewPt(0, 1, 0), NewVec(0, 1, 0)
+	pairs := []ClipSpace{ClipSpaceVulkan, ClipSpaceOpenGL, ClipSpaceDirect3D}
+
+	for _, from := range pairs {
+		for _, to := range pairs {
+			if from.LeftHanded != to.LeftHanded {
+				continue
+			}
+			vpFrom := from.PerspectiveDeg(60, 1.5, 0.1, 100).MultM(from.LookAt(eye, focus, up))
+			vpTo := to.PerspectiveDeg(60, 1.5, 0.1, 100).MultM(to.LookAt(eye, focus, up))
+
+			res := NewMatClipSpaceConversion(from, to).MultM(vpFrom)
+			if !res.ApproximatelyEquals(vpTo, 0.0001) {
+				t.Errorf("Conversion from %+v to %+v failed! Expected: %+v Actual: %+v", from, to, vpTo, res)
+			}
+		}
+	}
+}
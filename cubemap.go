@@ -0,0 +1,36 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// cubeFaces holds the look direction and up vector for each cube map face, in Vulkan's face order.
+var cubeFaces = [6][2]Vec{
+	{{1, 0, 0, 0}, {0, -1, 0, 0}},  // +X
+	{{-1, 0, 0, 0}, {0, -1, 0, 0}}, // -X
+	{{0, 1, 0, 0}, {0, 0, 1, 0}},   // +Y
+	{{0, -1, 0, 0}, {0, 0, -1, 0}}, // -Y
+	{{0, 0, 1, 0}, {0, -1, 0, 0}},  // +Z
+	{{0, 0, -1, 0}, {0, -1, 0, 0}}, // -Z
+}
+
+// CubeMapViews returns the six view matricies for rendering a cube map centered on pos, in Vulkan's face (array layer)
+// order: +X, -X, +Y, -Y, +Z, -Z. Each view is built with [Camera], and must be combined with [CubeMapProjection] so
+// that the rendered images match the orientation Vulkan uses when sampling a cube map.
+func CubeMapViews(pos Pt) [6]Mat {
+	var rval [6]Mat
+	for i, f := range cubeFaces {
+		rval[i] = Camera(pos, f[0], f[1])
+	}
+	return rval
+}
+
+// CubeMapProjection returns the square, 90 degree field of view projection matrix for rendering cube map faces with
+// the views from [CubeMapViews]. near and far have the same meaning as in [Perspective].
+//
+// Cube map faces are sampled with a left-handed orientation, so this projection mirrors the image vertically compared
+// to [Perspective]. As a result, triangle winding is reversed: swap the pipeline's frontFace (or cullMode) for cube
+// map passes.
+func CubeMapProjection(near, far float32) Mat {
+	rval := Perspective(math32.Pi/2, 1, near, far)
+	rval[1][1] = -rval[1][1]
+	return rval
+}
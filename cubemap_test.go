@@ -0,0 +1,64 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCubeMapViews(t *testing.T) {
+	pos := NewPt(3, -2, 5)
+	views := CubeMapViews(pos)
+	proj := CubeMapProjection(0.1, 100)
+
+	// Vulkan's cube map face selection: the major axis selects the face, and sc and tc (divided by the major axis
+	// magnitude) are the texture coordinates on that face, in the range [-1..1]
+	faceCoords := func(d Vec) (int, float32, float32) {
+		x, y, z := d[0], d[1], d[2]
+		ax, ay, az := math32.Abs(x), math32.Abs(y), math32.Abs(z)
+		switch {
+		case ax >= ay && ax >= az && x > 0:
+			return 0, -z / ax, -y / ax
+		case ax >= ay && ax >= az:
+			return 1, z / ax, -y / ax
+		case ay >= az && y > 0:
+			return 2, x / ay, z / ay
+		case ay >= az:
+			return 3, x / ay, -z / ay
+		case z > 0:
+			return 4, x / az, -y / az
+		default:
+			return 5, -x / az, -y / az
+		}
+	}
+
+	dirs := []Vec{
+		NewVec(1, 0.2, -0.3), NewVec(-1, 0.5, 0.1), NewVec(0.3, 1, -0.6), NewVec(-0.2, -1, 0.4),
+		NewVec(0.7, -0.2, 1), NewVec(-0.5, 0.6, -1), NewVec(2, 1, 1), NewVec(0.1, -3, 2),
+	}
+
+	for _, d := range dirs {
+		face, sc, tc := faceCoords(d)
+		p := pos.Add(d.Scale(10))
+
+		clip := proj.MultM(views[face]).MultP(p)
+		if testClipped(clip) {
+			t.Errorf("Direction %+v was clipped on face %d! Result: %+v", d, face, clip)
+			continue
+		}
+		ndc := clip.Homogenize()
+		if math32.Abs(ndc[0]-sc) > 0.0001 || math32.Abs(ndc[1]-tc) > 0.0001 {
+			t.Errorf("Direction %+v rendered to the wrong position on face %d! Expected: (%v, %v) Actual: %+v", d, face, sc, tc, ndc)
+		}
+
+		// Every other face must clip the point, apart from points exactly on an edge
+		for other := range views {
+			if other == face {
+				continue
+			}
+			if r := proj.MultM(views[other]).MultP(p); !testClipped(r) {
+				t.Errorf("Direction %+v for face %d was also visible on face %d! Result: %+v", d, face, other, r)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package vkm
+
+// Decomposition holds the individual components of a transformation matrix, as returned by [Mat.DecomposeFull]. The
+// original matrix is recomposed, in this order, as Perspective x Translation x Rotation x Shear x Scale. See
+// [Decomposition.AsMat].
+type Decomposition struct {
+	// Translation is the translation vector, as would be passed to [NewMatTranslate].
+	Translation Vec
+	// Rotation is a unit quaternion.
+	Rotation Quat
+	// Scale holds the scale factor along each axis, as would be passed to [NewMatScale]. If the matrix contains a
+	// reflection, the X scale factor will be negative.
+	Scale Vec
+	// Shear holds the XY, XZ, and YZ shear factors in components 0, 1, and 2 respectively.
+	Shear Vec
+	// Perspective is the bottom row of the matrix, with all four components meaningful. A matrix without a perspective
+	// component has a Perspective of {0, 0, 0, 1}.
+	Perspective Vec
+}
+
+// Decompose splits the affine transformation m into translation, rotation, and scale, such that
+//
+//	Identity().Scale(scale).RotateQuat(rotation).Translate(translation)
+//
+// reproduces m. A reflection in m (i.e. a negative determinant) is returned as a negative X scale. Any shear or
+// perspective in m is discarded; use [Mat.DecomposeFull] if m may contain those.
+//
+// If m is singular, all three results are zero values. Note that a zero Quat is not a valid rotation; use
+// [Mat.DecomposeChecked] if m may be singular.
+func (m Mat) Decompose() (translation Vec, rotation Quat, scale Vec) {
+	translation, rotation, scale, _ = m.DecomposeChecked()
+	return
+}
+
+// DecomposeChecked is identical to [Mat.Decompose], but also returns ErrSingularMatrix if m cannot be decomposed. See
+// [Mat.DecomposeFull] for when that happens.
+func (m Mat) DecomposeChecked() (translation Vec, rotation Quat, scale Vec, err error) {
+	d, err := m.DecomposeFull()
+	return d.Translation, d.Rotation, d.Scale, err
+}
+
+// DecomposeFull splits m into perspective, translation, rotation, shear, and scale components. ErrSingularMatrix is
+// returned if m cannot be decomposed, which happens when any scale factor is zero or when the perspective
+// component cannot be separated. This is the "unmatrix" algorithm from Graphics Gems II.
+//
+// m is first divided by m[3][3], so the recomposed matrix will equal m only up to that (homogenous) scale factor.
+func (m Mat) DecomposeFull() (Decomposition, error) {
+	w := m[3][3]
+	if w == 0 {
+		return Decomposition{}, ErrSingularMatrix
+	}
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] /= w
+		}
+	}
+
+	d := Decomposition{Perspective: Vec{0, 0, 0, 1}}
+
+	if m[0][3] != 0 || m[1][3] != 0 || m[2][3] != 0 {
+		// Solve for the perspective row using the matrix with its perspective removed
+		p := m
+		p[0][3], p[1][3], p[2][3], p[3][3] = 0, 0, 0, 1
+		pInv, err := p.InverseChecked(0)
+		if err != nil {
+			return Decomposition{}, err
+		}
+		d.Perspective = pInv.Transpose().MultV(Vec{m[0][3], m[1][3], m[2][3], m[3][3]})
+		m[0][3], m[1][3], m[2][3], m[3][3] = 0, 0, 0, 1
+	}
+
+	d.Translation = NewVec(m[3][0], m[3][1], m[3][2])
+
+	// Gram-Schmidt orthogonalization of the columns, collecting scale and shear along the way
+	c := m.UpperLeft3()
+
+	sx := c[0].Length()
+	c[0] = c[0].Scale(1 / sx)
+
+	shXY := c[0].Dot(c[1])
+	c[1] = c[1].Sub(c[0].Scale(shXY))
+	sy := c[1].Length()
+	c[1] = c[1].Scale(1 / sy)
+
+	shXZ := c[0].Dot(c[2])
+	c[2] = c[2].Sub(c[0].Scale(shXZ))
+	shYZ := c[1].Dot(c[2])
+	c[2] = c[2].Sub(c[1].Scale(shYZ))
+	sz := c[2].Length()
+	c[2] = c[2].Scale(1 / sz)
+
+	if !(sx > 0 && sy > 0 && sz > 0) {
+		return Decomposition{}, ErrSingularMatrix
+	}
+
+	if c.Determinant() < 0 {
+		// The matrix contains a reflection. Move it into the X scale so the rotation is proper, which also flips the
+		// sign of the shears that depend on the X axis.
+		c[0] = c[0].Invert()
+		sx, shXY, shXZ = -sx, -shXY, -shXZ
+	}
+
+	d.Scale = NewVec(sx, sy, sz)
+	d.Shear = NewVec(shXY/sy, shXZ/sz, shYZ/sz)
+	d.Rotation = NewQuatFromMat(c.Homogenize()).Normalize()
+
+	return d, nil
+}
+
+// AsMat recomposes the components of d into a single transformation matrix.
+func (d Decomposition) AsMat() Mat {
+	shear := Mat{
+		{1, 0, 0, 0},
+		{d.Shear[0], 1, 0, 0},
+		{d.Shear[1], d.Shear[2], 1, 0},
+		{0, 0, 0, 1},
+	}
+	persp := Identity()
+	for i := range persp {
+		persp[i][3] = d.Perspective[i]
+	}
+
+	return persp.
+		MultM(NewMatTranslate(d.Translation)).
+		MultM(d.Rotation.AsMat()).
+		MultM(shear).
+		MultM(NewMatScale(d.Scale))
+}
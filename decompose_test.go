@@ -0,0 +1,83 @@
+package vkm
+
+import "testing"
+
+func TestDecompose(t *testing.T) {
+	cases := []struct {
+		translation Vec
+		rotation    Quat
+		scale       Vec
+	}{
+		{NewVec(0, 0, 0), IdentityQuat(), NewVec(1, 1, 1)},
+		{NewVec(1, -2, 3), NewQuatDeg(UnitVecY(), 45), NewVec(2, 3, 4)},
+		{NewVec(-5, 0, 10), NewQuatDeg(NewVec(1, 2, 3).Normalize(), 170), NewVec(0.5, 0.25, 8)},
+		{NewVec(1, 1, 1), NewQuatDeg(UnitVecZ(), -90), NewVec(-2, 1, 1)}, // reflection
+	}
+
+	for i, c := range cases {
+		m := NewMatTranslate(c.translation).
+			MultM(NewMatRotate(c.rotation.AxisAngle())).
+			MultM(NewMatScale(c.scale))
+
+		tr, rot, sc := m.Decompose()
+		if !Pt(tr).EqualTo(Pt(c.translation)) {
+			t.Errorf("Case %d: translation mismatch! Expected: %+v Actual: %+v", i, c.translation, tr)
+		}
+		if rot.Dot(c.rotation) < 0 {
+			rot = Quat{-rot[0], -rot[1], -rot[2], -rot[3]}
+		}
+		if !rot.ApproximatelyEquals(c.rotation, 0.0001) {
+			t.Errorf("Case %d: rotation mismatch! Expected: %+v Actual: %+v", i, c.rotation, rot)
+		}
+		if !Pt(sc).EqualTo(Pt(c.scale)) {
+			t.Errorf("Case %d: scale mismatch! Expected: %+v Actual: %+v", i, c.scale, sc)
+		}
+
+		res := Identity().Scale(sc).RotateQuat(rot).Translate(tr)
+		if !res.ApproximatelyEquals(m, 0.0001) {
+			t.Errorf("Case %d: recomposed matrix mismatch! Expected: %+v Actual: %+v", i, m, res)
+		}
+		if _, _, _, err := m.DecomposeChecked(); err != nil {
+			t.Errorf("Case %d: DecomposeChecked returned an error: %v", i, err)
+		}
+	}
+
+	singular := NewMatScale(NewVec(1, 0, 1)).Translate(NewVec(1, 2, 3))
+	if _, _, _, err := singular.DecomposeChecked(); err != ErrSingularMatrix {
+		t.Errorf("DecomposeChecked of a singular matrix did not fail! Expected: %v Actual: %v", ErrSingularMatrix, err)
+	}
+	if tr, rot, sc := singular.Decompose(); tr != (Vec{}) || rot != (Quat{}) || sc != (Vec{}) {
+		t.Errorf("Decompose of a singular matrix did not return zero values! Actual: %+v %+v %+v", tr, rot, sc)
+	}
+}
+
+func TestDecomposeFull(t *testing.T) {
+	d := Decomposition{
+		Translation: NewVec(3, 2, 1),
+		Rotation:    NewQuatDeg(NewVec(0, 1, 1).Normalize(), 60),
+		Scale:       NewVec(-1, 2, 3),
+		Shear:       NewVec(0.5, -0.25, 0.1),
+		Perspective: Vec{0.1, 0.2, 0.3, 1},
+	}
+	m := d.AsMat()
+	// Homogenous matricies are equivalent under scale, and decomposition normalizes to m[3][3] = 1
+	w := m[3][3]
+	for i := range m {
+		m[i] = m[i].Scale(1 / w)
+	}
+
+	res, err := m.DecomposeFull()
+	if err != nil {
+		t.Fatalf("DecomposeFull returned an error: %v", err)
+	}
+	if rm := res.AsMat(); !rm.ApproximatelyEquals(m, 0.0001) {
+		t.Errorf("Recomposed matrix mismatch! Expected: %+v Actual: %+v", m, rm)
+	}
+	if !Pt(res.Shear).EqualTo(Pt(d.Shear)) {
+		t.Errorf("Shear mismatch! Expected: %+v Actual: %+v", d.Shear, res.Shear)
+	}
+
+	if _, err := NewMatScale(NewVec(1, 0, 1)).DecomposeFull(); err != ErrSingularMatrix {
+		t.Errorf("DecomposeFull on a zero scale did not return ErrSingularMatrix! Actual: %v", err)
+	}
+}
@@ -2,7 +2,8 @@
 // It was built for use with go-vk (https://github.com/bbredesen/go-vk), but should be
 // appropriate for any graphics API expecting column-major matricies.
 //
-// All matricies are 4x4. The Vec and Pt types are 4-component, homogenous column
+// The primary matrix type, Mat, is 4x4. A 3x3 Mat3 is also provided for normal matricies and 2D
+// transformations. The Vec and Pt types are 4-component, homogenous column
 // vectors. You can directly instantiate them via:
 //  v := Vec{i, j, k, 0}
 //  p := Pt{x, y, z, 1}
@@ -0,0 +1,165 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// EulerOrder selects the sequence of axes used to build or extract Euler angles. The name lists the axes in the order
+// the rotations are applied. Six orders use three distinct axes (Tait-Bryan angles, e.g. yaw/pitch/roll), and six
+// repeat the first axis (proper Euler angles).
+//
+// Every Euler function comes in an extrinsic form, where each rotation is about the fixed world axes, and an intrinsic
+// form, where each rotation is about the axes of the already-rotated frame. An intrinsic rotation in XYZ order is the
+// same as an extrinsic rotation in ZYX order with the angles reversed.
+type EulerOrder uint8
+
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYZX
+	EulerYXZ
+	EulerZXY
+	EulerZYX
+	EulerXYX
+	EulerXZX
+	EulerYZY
+	EulerYXY
+	EulerZXZ
+	EulerZYZ
+)
+
+// eulerAxes maps each order to the axis index (0 = X, 1 = Y, 2 = Z) of each rotation, in the order they are applied.
+var eulerAxes = [...][3]int{
+	EulerXYZ: {0, 1, 2},
+	EulerXZY: {0, 2, 1},
+	EulerYZX: {1, 2, 0},
+	EulerYXZ: {1, 0, 2},
+	EulerZXY: {2, 0, 1},
+	EulerZYX: {2, 1, 0},
+	EulerXYX: {0, 1, 0},
+	EulerXZX: {0, 2, 0},
+	EulerYZY: {1, 2, 1},
+	EulerYXY: {1, 0, 1},
+	EulerZXZ: {2, 0, 2},
+	EulerZYZ: {2, 1, 2},
+}
+
+// reverse returns the order with the axis sequence reversed, used to convert between intrinsic and extrinsic angles.
+func (o EulerOrder) reverse() EulerOrder {
+	a := eulerAxes[o]
+	for r, b := range eulerAxes {
+		if b[0] == a[2] && b[1] == a[1] && b[2] == a[0] {
+			return EulerOrder(r)
+		}
+	}
+	return o
+}
+
+func newMatRotateAxis(axis int, theta float32) Mat {
+	switch axis {
+	case 0:
+		return NewMatRotateX(theta)
+	case 1:
+		return NewMatRotateY(theta)
+	default:
+		return NewMatRotateZ(theta)
+	}
+}
+
+// NewMatEuler generates a rotation from extrinsic Euler angles: a rotation of a radians around the first axis in order,
+// followed by b radians around the second, and c radians around the third, all measured against the fixed world axes.
+func NewMatEuler(order EulerOrder, a, b, c float32) Mat {
+	axes := eulerAxes[order]
+	return newMatRotateAxis(axes[2], c).
+		MultM(newMatRotateAxis(axes[1], b)).
+		MultM(newMatRotateAxis(axes[0], a))
+}
+
+// NewMatEulerDeg generates a rotation from extrinsic Euler angles measured in degrees. See [NewMatEuler].
+func NewMatEulerDeg(order EulerOrder, a, b, c float32) Mat {
+	return NewMatEuler(order, 2*math32.Pi*a/360.0, 2*math32.Pi*b/360.0, 2*math32.Pi*c/360.0)
+}
+
+// NewMatEulerIntrinsic generates a rotation from intrinsic Euler angles: a rotation of a radians around the first axis
+// in order, followed by b radians around the second axis of the rotated frame, and c radians around the third axis of
+// the twice-rotated frame.
+func NewMatEulerIntrinsic(order EulerOrder, a, b, c float32) Mat {
+	return NewMatEuler(order.reverse(), c, b, a)
+}
+
+// NewMatEulerIntrinsicDeg generates a rotation from intrinsic Euler angles measured in degrees. See
+// [NewMatEulerIntrinsic].
+func NewMatEulerIntrinsicDeg(order EulerOrder, a, b, c float32) Mat {
+	return NewMatEulerIntrinsic(order, 2*math32.Pi*a/360.0, 2*math32.Pi*b/360.0, 2*math32.Pi*c/360.0)
+}
+
+// RotateEuler applies a rotation by extrinsic Euler angles to m and returns the resulting matrix.
+func (m Mat) RotateEuler(order EulerOrder, a, b, c float32) Mat {
+	return NewMatEuler(order, a, b, c).MultM(m)
+}
+
+// RotateEulerDeg applies a rotation by extrinsic Euler angles, measured in degrees, to m and returns the resulting
+// matrix.
+func (m Mat) RotateEulerDeg(order EulerOrder, a, b, c float32) Mat {
+	return NewMatEulerDeg(order, a, b, c).MultM(m)
+}
+
+// Euler extracts extrinsic Euler angles in radians from the rotation in the upper 3x3 of m, such that
+// NewMatEuler(order, a, b, c) reproduces the rotation. m is assumed to be a pure rotation matrix.
+//
+// For Tait-Bryan orders, b is in the range [-pi/2, pi/2]; for proper Euler orders, b is in the range [0, pi]. At
+// gimbal lock, where the first and third axes line up and only their sum (or difference) is meaningful, c is set to
+// zero and the full rotation is returned in a.
+func (m Mat) Euler(order EulerOrder) (a, b, c float32) {
+	// Ken Shoemake's method from Graphics Gems IV. r(row, col) addresses m in row-major terms.
+	r := func(row, col int) float32 { return m[col][row] }
+
+	axes := eulerAxes[order]
+	i, j := axes[0], axes[1]
+	k := 3 - i - j
+	// Odd parity orders (e.g. XZY) run against the X -> Y -> Z -> X cycle and flip the sign of every angle.
+	odd := (i+1)%3 != j
+	const eps = 0.000001
+
+	if axes[0] == axes[2] {
+		sy := math32.Hypot(r(i, j), r(i, k))
+		b = math32.Atan2(sy, r(i, i))
+		if sy > eps {
+			a = math32.Atan2(r(i, j), r(i, k))
+			c = math32.Atan2(r(j, i), -r(k, i))
+		} else {
+			a = math32.Atan2(-r(j, k), r(j, j))
+		}
+	} else {
+		cy := math32.Hypot(r(i, i), r(j, i))
+		b = math32.Atan2(-r(k, i), cy)
+		if cy > eps {
+			a = math32.Atan2(r(k, j), r(k, k))
+			c = math32.Atan2(r(j, i), r(i, i))
+		} else {
+			a = math32.Atan2(-r(j, k), r(j, j))
+		}
+	}
+
+	if odd {
+		a, b, c = -a, -b, -c
+	}
+	return
+}
+
+// EulerDeg extracts extrinsic Euler angles in degrees from m. See [Mat.Euler].
+func (m Mat) EulerDeg(order EulerOrder) (a, b, c float32) {
+	a, b, c = m.Euler(order)
+	return a * 360.0 / (2 * math32.Pi), b * 360.0 / (2 * math32.Pi), c * 360.0 / (2 * math32.Pi)
+}
+
+// EulerIntrinsic extracts intrinsic Euler angles in radians from m, such that NewMatEulerIntrinsic(order, a, b, c)
+// reproduces the rotation. At gimbal lock, a is set to zero and the full rotation is returned in c. See [Mat.Euler].
+func (m Mat) EulerIntrinsic(order EulerOrder) (a, b, c float32) {
+	c, b, a = m.Euler(order.reverse())
+	return
+}
+
+// EulerIntrinsicDeg extracts intrinsic Euler angles in degrees from m. See [Mat.EulerIntrinsic].
+func (m Mat) EulerIntrinsicDeg(order EulerOrder) (a, b, c float32) {
+	c, b, a = m.EulerDeg(order.reverse())
+	return
+}
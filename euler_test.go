@@ -0,0 +1,63 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestNewMatEuler(t *testing.T) {
+	// Yaw 90 degrees around Y, then pitch 90 degrees around the world X axis
+	m := NewMatEulerDeg(EulerYXZ, 90, 90, 0)
+	exp := Identity().RotateYDeg(90).RotateXDeg(90)
+	if !m.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("NewMatEulerDeg failed! Expected: %+v Actual: %+v", exp, m)
+	}
+
+	// Intrinsic XYZ is extrinsic ZYX with the angles reversed
+	mi := NewMatEulerIntrinsic(EulerXYZ, 0.1, 0.2, 0.3)
+	me := NewMatEuler(EulerZYX, 0.3, 0.2, 0.1)
+	if !mi.ApproximatelyEquals(me, 0.00001) {
+		t.Errorf("Intrinsic and extrinsic forms did not match! Expected: %+v Actual: %+v", me, mi)
+	}
+	me = NewMatRotateX(0.1).MultM(NewMatRotateY(0.2)).MultM(NewMatRotateZ(0.3))
+	if !mi.ApproximatelyEquals(me, 0.00001) {
+		t.Errorf("Intrinsic form did not rotate around the local axes! Expected: %+v Actual: %+v", me, mi)
+	}
+}
+
+func TestEulerRoundTrip(t *testing.T) {
+	angles := [][3]float32{
+		{0.3, -0.7, 1.2},
+		{-2.5, 0.4, 3.0},
+		{1, 1.5, -1},
+		{0, 0, 0},
+		{0.5, math32.Pi / 2, 0},  // Tait-Bryan gimbal lock
+		{0.5, -math32.Pi / 2, 0}, // Tait-Bryan gimbal lock
+		{0.5, 0, 0},              // Proper Euler gimbal lock
+		{0.5, math32.Pi, 0},      // Proper Euler gimbal lock
+	}
+
+	for order := EulerXYZ; order <= EulerZYZ; order++ {
+		for _, ang := range angles {
+			m := NewMatEuler(order, ang[0], ang[1], ang[2])
+			a, b, c := m.Euler(order)
+			if res := NewMatEuler(order, a, b, c); !res.ApproximatelyEquals(m, 0.0001) {
+				t.Errorf("Extrinsic round trip failed for order %d, angles %v! Extracted: %v, %v, %v", order, ang, a, b, c)
+			}
+
+			m = NewMatEulerIntrinsic(order, ang[0], ang[1], ang[2])
+			a, b, c = m.EulerIntrinsic(order)
+			if res := NewMatEulerIntrinsic(order, a, b, c); !res.ApproximatelyEquals(m, 0.0001) {
+				t.Errorf("Intrinsic round trip failed for order %d, angles %v! Extracted: %v, %v, %v", order, ang, a, b, c)
+			}
+		}
+	}
+}
+
+func TestEulerDeg(t *testing.T) {
+	a, b, c := NewMatEulerDeg(EulerZYX, 30, 45, 60).EulerDeg(EulerZYX)
+	if math32.Abs(a-30) > 0.001 || math32.Abs(b-45) > 0.001 || math32.Abs(c-60) > 0.001 {
+		t.Errorf("EulerDeg failed! Expected: 30, 45, 60 Actual: %v, %v, %v", a, b, c)
+	}
+}
@@ -0,0 +1,81 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// FlyCamera is a first-person camera controller, for WASD and mouse-look style navigation. The camera sits at Position
+// and faces the direction given by Yaw and Pitch (in radians) around the Up axis. At zero yaw and pitch it looks down
+// -Z, or down -X if Up is close to the Z axis. Roll, for flight simulators, banks the view around the look direction.
+//
+// All fields may be set directly. The zero value is not usable; create a FlyCamera with [NewFlyCamera].
+type FlyCamera struct {
+	Position Pt
+	Yaw      float32
+	Pitch    float32
+	// Roll banks the camera around its forward axis, in radians. Positive roll tilts the view's up toward its right, as
+	// in a right-hand turn.
+	Roll float32
+	Up   Vec
+
+	// MinPitch and MaxPitch limit Pitch. The defaults stop just short of looking straight up or down, where the view
+	// matrix would be undefined.
+	MinPitch, MaxPitch float32
+}
+
+// NewFlyCamera creates a first-person camera at position, with the provided up axis.
+func NewFlyCamera(position Pt, up Vec) *FlyCamera {
+	const maxPitch = math32.Pi/2 - 0.001
+	return &FlyCamera{
+		Position: position,
+		Up:       up,
+		MinPitch: -maxPitch,
+		MaxPitch: maxPitch,
+	}
+}
+
+// Forward returns the unit vector the camera is looking along.
+func (c *FlyCamera) Forward() Vec {
+	return yawPitchDirection(c.Up, c.Yaw, -c.Pitch).Invert()
+}
+
+// Right returns the unit vector pointing to the right of the view, including any roll.
+func (c *FlyCamera) Right() Vec {
+	return c.Forward().Cross(c.viewUp()).Normalize()
+}
+
+// viewUp returns the up vector for the view, rolled around the forward axis.
+func (c *FlyCamera) viewUp() Vec {
+	up := c.Up.Normalize()
+	if c.Roll == 0 {
+		return up
+	}
+	return NewQuat(c.Forward(), c.Roll).RotateV(up)
+}
+
+// View returns the view matrix for the camera's current state, built with [Camera].
+func (c *FlyCamera) View() Mat {
+	return Camera(c.Position, c.Forward(), c.viewUp())
+}
+
+// MoveForward moves the camera distance units along its look direction, including pitch. Negative distances move
+// backward.
+func (c *FlyCamera) MoveForward(distance float32) {
+	c.Position = c.Position.Add(c.Forward().Scale(distance))
+}
+
+// Strafe moves the camera distance units to the right of the view, or to the left if distance is negative.
+func (c *FlyCamera) Strafe(distance float32) {
+	c.Position = c.Position.Add(c.Right().Scale(distance))
+}
+
+// Elevate moves the camera distance units along the Up axis, regardless of where it is looking.
+func (c *FlyCamera) Elevate(distance float32) {
+	c.Position = c.Position.Add(c.Up.Normalize().Scale(distance))
+}
+
+// Look turns the camera by dx radians to the right and dy radians down, matching the direction of mouse movement in
+// window coordinates. Scale mouse deltas by the desired sensitivity before calling Look. Pitch is clamped to
+// [MinPitch..MaxPitch].
+func (c *FlyCamera) Look(dx, dy float32) {
+	c.Yaw = math32.Remainder(c.Yaw-dx, 2*math32.Pi)
+	c.Pitch = clamp(c.Pitch-dy, c.MinPitch, c.MaxPitch)
+}
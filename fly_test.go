@@ -0,0 +1,65 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestFlyCamera(t *testing.T) {
+	pos := NewPt(1, 2, 3)
+	c := NewFlyCamera(pos, UnitVecY())
+
+	if exp, res := Camera(pos, UnitVecZ().Invert(), UnitVecY()), c.View(); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Default fly camera did not look down -Z! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	c.MoveForward(2)
+	c.Strafe(1)
+	c.Elevate(-0.5)
+	if exp := NewPt(2, 1.5, 1); !c.Position.EqualTo(exp) {
+		t.Errorf("Movement failed! Expected: %+v Actual: %+v", exp, c.Position)
+	}
+
+	c.Look(math32.Pi/2, 0)
+	if exp, res := Pt(UnitVecX()), Pt(c.Forward()); !res.EqualTo(exp) {
+		t.Errorf("Looking right did not turn toward +X! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	c.Look(0, -math32.Pi/4)
+	if f := c.Forward(); math32.Abs(f[1]-math32.Sqrt2/2) > 0.00001 {
+		t.Errorf("Looking up 45 degrees failed! Actual forward: %+v", f)
+	}
+
+	c.Look(0, -10)
+	if c.Pitch != c.MaxPitch {
+		t.Errorf("Pitch was not clamped! Expected: %v Actual: %v", c.MaxPitch, c.Pitch)
+	}
+}
+
+func TestFlyCameraRoll(t *testing.T) {
+	c := NewFlyCamera(Origin(), UnitVecY())
+	c.Roll = math32.Pi / 2
+
+	// Banked 90 degrees to the right, world +X is at the top of the screen (negative Y in Vulkan)
+	res := c.View().MultP(NewPt(1, 0, -1))
+	if math32.Abs(res[0]) > 0.00001 || res[1] >= 0 {
+		t.Errorf("Roll did not bank the view to the right! Actual: %+v", res)
+	}
+	if exp, r := Pt(UnitVecY().Invert()), Pt(c.Right()); !r.EqualTo(exp) {
+		t.Errorf("Right did not follow the roll! Expected: %+v Actual: %+v", exp, r)
+	}
+}
+
+func TestFlyCameraZUp(t *testing.T) {
+	c := NewFlyCamera(Origin(), UnitVecZ())
+	c.Elevate(2)
+	if exp := NewPt(0, 0, 2); !c.Position.EqualTo(exp) {
+		t.Errorf("Elevate did not follow the Z up axis! Expected: %+v Actual: %+v", exp, c.Position)
+	}
+
+	c.Look(0, -0.5)
+	if f := c.Forward(); f[2] <= 0 {
+		t.Errorf("Looking up did not raise the forward vector toward +Z! Actual: %+v", f)
+	}
+}
@@ -0,0 +1,124 @@
+package vkm
+
+// Containment is the result of testing a volume against a [Frustum].
+type Containment int
+
+const (
+	// Outside means the volume is entirely outside the frustum and can be culled.
+	Outside Containment = iota
+	// Intersecting means the volume may cross the boundary of the frustum. Tests against bounding volumes are
+	// conservative, so a volume near a corner of the frustum may be reported as Intersecting while actually being
+	// outside.
+	Intersecting
+	// Inside means the volume is entirely inside the frustum.
+	Inside
+)
+
+// Indices of each plane within Frustum.Planes. Top and bottom follow Vulkan's clip space, where positive Y is down the
+// screen.
+const (
+	FrustumLeft = iota
+	FrustumRight
+	FrustumTop
+	FrustumBottom
+	FrustumNear
+	FrustumFar
+)
+
+// Frustum is a view volume bounded by six planes, with each plane's normal pointing into the volume. Use it to cull
+// objects that cannot be visible before submitting them for rendering.
+type Frustum struct {
+	Planes [6]Plane
+}
+
+// NewFrustum extracts the frustum planes from a combined view-projection matrix, e.g. proj.MultM(view), using the
+// Gribb-Hartmann method. The planes are in world space. If viewProj is only a projection matrix, the planes are in view
+// space. The matrix is assumed to target Vulkan's clip space, with z in the range [0..w].
+//
+// Culling results are the same for reversed depth projections such as [InvertedDepthPerspective], but the near and far
+// planes will be swapped. Use [NewInvertedDepthFrustum] in that case if you need to address the planes individually.
+//
+// If the far plane is at infinity, the far plane has a zero normal and every point is considered inside it.
+func NewFrustum(viewProj Mat) Frustum {
+	m := viewProj.Transpose() // Rows of viewProj are now addressable as m[i]
+	return Frustum{[6]Plane{
+		FrustumLeft:   planeFromRow(m[3].add4(m[0])),
+		FrustumRight:  planeFromRow(m[3].sub4(m[0])),
+		FrustumTop:    planeFromRow(m[3].add4(m[1])),
+		FrustumBottom: planeFromRow(m[3].sub4(m[1])),
+		FrustumNear:   planeFromRow(m[2]),
+		FrustumFar:    planeFromRow(m[3].sub4(m[2])),
+	}}
+}
+
+// NewInvertedDepthFrustum extracts the frustum planes from a view-projection matrix built with a reversed depth
+// projection, such as [InvertedDepthPerspective], where the near plane maps to a depth of 1 and the far plane to 0. See
+// [NewFrustum].
+func NewInvertedDepthFrustum(viewProj Mat) Frustum {
+	f := NewFrustum(viewProj)
+	f.Planes[FrustumNear], f.Planes[FrustumFar] = f.Planes[FrustumFar], f.Planes[FrustumNear]
+	return f
+}
+
+// ContainsPt returns true if p is inside f or on its boundary.
+func (f Frustum) ContainsPt(p Pt) bool {
+	for _, pl := range f.Planes {
+		if pl.SignedDistance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestSphere tests the sphere at center with the provided radius against f.
+func (f Frustum) TestSphere(center Pt, radius float32) Containment {
+	rval := Inside
+	for _, pl := range f.Planes {
+		d := pl.SignedDistance(center)
+		if d < -radius {
+			return Outside
+		}
+		if d < radius {
+			rval = Intersecting
+		}
+	}
+	return rval
+}
+
+// TestAABB tests the box b against f.
+func (f Frustum) TestAABB(b AABB) Containment {
+	c, e := b.Center(), b.Extents()
+	rval := Inside
+	for _, pl := range f.Planes {
+		// Project the extents onto the plane normal to find the box's "radius" in that direction
+		r := absVec(pl.Normal).Dot(e)
+		d := pl.SignedDistance(c)
+		if d < -r {
+			return Outside
+		}
+		if d < r {
+			rval = Intersecting
+		}
+	}
+	return rval
+}
+
+// TestAABBs tests every box in boxes against f, appending the results to dst[:0] and returning the resulting slice.
+// Pass the slice from the previous frame as dst to avoid allocating.
+func (f Frustum) TestAABBs(boxes []AABB, dst []Containment) []Containment {
+	dst = dst[:0]
+	for _, b := range boxes {
+		dst = append(dst, f.TestAABB(b))
+	}
+	return dst
+}
+
+// TestSpheres tests every sphere, given as parallel slices of centers and radii, against f, appending the results to
+// dst[:0] and returning the resulting slice. centers and radii must be the same length.
+func (f Frustum) TestSpheres(centers []Pt, radii []float32, dst []Containment) []Containment {
+	dst = dst[:0]
+	for i, c := range centers {
+		dst = append(dst, f.TestSphere(c, radii[i]))
+	}
+	return dst
+}
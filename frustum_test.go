@@ -0,0 +1,92 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestNewFrustum(t *testing.T) {
+	view := LookAt(NewPt(0, 0, 10), Origin(), NewVec(0, 1, 0))
+
+	for _, tc := range []struct {
+		name string
+		f    Frustum
+	}{
+		{"standard", NewFrustum(PerspectiveDeg(90, 1, 1, 100).MultM(view))},
+		{"inverted", NewInvertedDepthFrustum(InvertedDepthPerspective(math32.Pi/2, 1, 1, 100).MultM(view))},
+	} {
+		// The camera looks down -Z from z = 10, so the near plane is at z = 9 and the far plane at z = -90
+		if d := tc.f.Planes[FrustumNear].SignedDistance(NewPt(0, 0, 9)); math32.Abs(d) > 0.0001 {
+			t.Errorf("%s: near plane is not 1 unit in front of the camera! Distance: %v", tc.name, d)
+		}
+		if d := tc.f.Planes[FrustumFar].SignedDistance(NewPt(0, 0, -90)); math32.Abs(d) > 0.001 {
+			t.Errorf("%s: far plane is not 100 units in front of the camera! Distance: %v", tc.name, d)
+		}
+
+		if !tc.f.ContainsPt(Origin()) {
+			t.Errorf("%s: focus point is not inside the frustum!", tc.name)
+		}
+		if tc.f.ContainsPt(NewPt(0, 0, 20)) {
+			t.Errorf("%s: point behind the camera is inside the frustum!", tc.name)
+		}
+		if tc.f.ContainsPt(NewPt(0, 0, -95)) {
+			t.Errorf("%s: point beyond the far plane is inside the frustum!", tc.name)
+		}
+		// The field of view is 90 degrees, so at 10 units distance the frustum is 20 units wide
+		if !tc.f.ContainsPt(NewPt(9.9, 0, 0)) || tc.f.ContainsPt(NewPt(10.1, 0, 0)) {
+			t.Errorf("%s: left/right planes are misplaced!", tc.name)
+		}
+		if !tc.f.ContainsPt(NewPt(0, -9.9, 0)) || tc.f.ContainsPt(NewPt(0, -10.1, 0)) {
+			t.Errorf("%s: top/bottom planes are misplaced!", tc.name)
+		}
+	}
+}
+
+func TestFrustumVolumes(t *testing.T) {
+	f := NewFrustum(PerspectiveDeg(90, 1, 1, 100))
+
+	spheres := []struct {
+		c   Pt
+		r   float32
+		exp Containment
+	}{
+		{NewPt(0, 0, -10), 1, Inside},
+		{NewPt(0, 0, -1), 0.5, Intersecting},
+		{NewPt(0, 0, 10), 1, Outside},
+		{NewPt(12, 0, -10), 1, Outside},
+		{NewPt(10, 0, -10), 1, Intersecting},
+	}
+	centers := make([]Pt, 0, len(spheres))
+	radii := make([]float32, 0, len(spheres))
+	for i, s := range spheres {
+		if res := f.TestSphere(s.c, s.r); res != s.exp {
+			t.Errorf("Sphere %d: expected %v, actual %v", i, s.exp, res)
+		}
+		centers = append(centers, s.c)
+		radii = append(radii, s.r)
+	}
+	res := f.TestSpheres(centers, radii, nil)
+	for i := range spheres {
+		if res[i] != spheres[i].exp {
+			t.Errorf("TestSpheres %d: expected %v, actual %v", i, spheres[i].exp, res[i])
+		}
+	}
+
+	boxes := []AABB{
+		NewAABB(NewPt(-1, -1, -11), NewPt(1, 1, -9)),
+		NewAABB(NewPt(-1, -1, -2), NewPt(1, 1, 0)),
+		NewAABB(NewPt(-1, -1, 1), NewPt(1, 1, 3)),
+		NewAABB(NewPt(50, -1, -11), NewPt(51, 1, -9)),
+	}
+	exp := []Containment{Inside, Intersecting, Outside, Outside}
+	resBoxes := f.TestAABBs(boxes, make([]Containment, 10))
+	if len(resBoxes) != len(boxes) {
+		t.Fatalf("TestAABBs returned %d results for %d boxes!", len(resBoxes), len(boxes))
+	}
+	for i := range boxes {
+		if resBoxes[i] != exp[i] {
+			t.Errorf("Box %d: expected %v, actual %v", i, exp[i], resBoxes[i])
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package vkm
+
+import "math"
+
+// JitterProjection offsets the projection matrix proj by a sub-pixel amount, as used for temporal anti-aliasing (TAA).
+// offset is measured in pixels, with positive X to the right and positive Y down the framebuffer, and width and height
+// are the size of the render target in pixels. The offset is applied in clip space, so this works for any projection,
+// including [Perspective], [InvertedDepthPerspective] and [OrthoProjection].
+//
+// Jitter offsets typically come from [HaltonJitter] or [R2Jitter]:
+//
+//	jittered := JitterProjection(proj, HaltonJitter(frame%8+1), width, height)
+func JitterProjection(proj Mat, offset Vec2, width, height float32) Mat {
+	// x' = x + dx*w, so the NDC position (after dividing by w) is shifted by dx
+	return NewMatTranslate(NewVec(2*offset[0]/width, 2*offset[1]/height, 0)).MultM(proj)
+}
+
+// Halton returns element index of the Halton (van der Corput) low-discrepancy sequence for the provided base, in the
+// range [0..1). Index 0 always returns 0, so sequences typically start from index 1.
+func Halton(index, base int) float32 {
+	f, r := 1.0, 0.0
+	for i := index; i > 0; i /= base {
+		f /= float64(base)
+		r += f * float64(i%base)
+	}
+	return float32(r)
+}
+
+// HaltonJitter returns element index of the Halton (2, 3) sequence as a pixel offset in the range [-0.5..0.5). Index 0
+// returns (-0.5, -0.5), the corner of the pixel, so start from index 1. A sequence length of 8 or 16 is
+// common for TAA.
+func HaltonJitter(index int) Vec2 {
+	return Vec2{Halton(index, 2) - 0.5, Halton(index, 3) - 0.5}
+}
+
+// r2Alpha holds the two generators of the R2 sequence: the reciprocals of the plastic number g and of g squared, where
+// g is the unique real root of x^3 = x + 1.
+var r2Alpha = [2]float64{0.7548776662466927, 0.5698402909980532}
+
+// R2Jitter returns element index of Martin Roberts' R2 low-discrepancy sequence as a pixel offset in the range
+// [-0.5..0.5). Unlike the Halton sequence, R2 has no preferred sequence length, so the index can simply be the frame
+// number.
+func R2Jitter(index int) Vec2 {
+	n := float64(index)
+	x := 0.5 + r2Alpha[0]*n
+	y := 0.5 + r2Alpha[1]*n
+	return Vec2{float32(x-math.Floor(x)) - 0.5, float32(y-math.Floor(y)) - 0.5}
+}
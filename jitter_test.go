@@ -0,0 +1,58 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHalton(t *testing.T) {
+	exp2 := []float32{0, 0.5, 0.25, 0.75, 0.125, 0.625}
+	exp3 := []float32{0, 1.0 / 3, 2.0 / 3, 1.0 / 9, 4.0 / 9, 7.0 / 9}
+	for i := range exp2 {
+		if h := Halton(i, 2); math32.Abs(h-exp2[i]) > 0.000001 {
+			t.Errorf("Halton(%d, 2) failed! Expected: %v Actual: %v", i, exp2[i], h)
+		}
+		if h := Halton(i, 3); math32.Abs(h-exp3[i]) > 0.000001 {
+			t.Errorf("Halton(%d, 3) failed! Expected: %v Actual: %v", i, exp3[i], h)
+		}
+	}
+}
+
+func TestJitterSequences(t *testing.T) {
+	for name, seq := range map[string]func(int) Vec2{"Halton": HaltonJitter, "R2": R2Jitter} {
+		var sum Vec2
+		const n = 256
+		for i := 1; i <= n; i++ {
+			j := seq(i)
+			if j[0] < -0.5 || j[0] >= 0.5 || j[1] < -0.5 || j[1] >= 0.5 {
+				t.Errorf("%s jitter %d is outside the pixel! Actual: %+v", name, i, j)
+			}
+			sum = sum.Add(j)
+		}
+		// A low-discrepancy sequence is evenly distributed, so the average offset is close to the pixel center
+		if avg := sum.Scale(1.0 / n); avg.Length() > 0.01 {
+			t.Errorf("%s jitter is not centered on the pixel! Average: %+v", name, avg)
+		}
+	}
+}
+
+func TestJitterProjection(t *testing.T) {
+	vp := NewViewport(0, 0, 800, 600)
+	offset := Vec2{0.25, -0.5}
+	p := NewPt(1, 2, -10)
+
+	for _, proj := range []Mat{
+		PerspectiveDeg(60, 800.0/600.0, 0.1, 100),
+		InvertedDepthPerspective(1, 800.0/600.0, 0.1, 100),
+		OrthoProjection(20, 15, 0.1, 100),
+	} {
+		base := vp.Project(p, Identity(), proj)
+		jittered := vp.Project(p, Identity(), JitterProjection(proj, offset, 800, 600))
+
+		exp := base.Add(Vec3{offset[0], offset[1], 0})
+		if !testApproxPt(jittered.Homogenize(), exp.Homogenize(), 0.001) {
+			t.Errorf("Jittered projection was not offset by %+v pixels! Expected: %+v Actual: %+v", offset, exp, jittered)
+		}
+	}
+}
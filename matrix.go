@@ -1,11 +1,15 @@
 package vkm
 
 import (
+	"errors"
 	"unsafe"
 
 	"github.com/chewxy/math32"
 )
 
+// ErrSingularMatrix is returned when attempting to invert a matrix with a determinant of (approximately) zero.
+var ErrSingularMatrix = errors.New("vkm: matrix is singular and cannot be inverted")
+
 // Mat is a column-major 4x4 matrix of float32s. Because it is fundamentally an array of arrays,
 // elements can be directly addressed via double brackets: m[col][row]
 type Mat [4]Vec
@@ -127,7 +131,7 @@ func (m Mat) Inverse() Mat {
 	d := m.Determinant()
 	return Mat{
 		{
-			(m[1][1]*m[2][2]*m[3][3] + m[1][2]*m[3][2]*m[1][3] + m[3][1]*m[1][2]*m[2][3] - m[3][1]*m[2][2]*m[1][3] - m[2][1]*m[1][2]*m[3][3] - m[1][1]*m[3][2]*m[2][3]) / d,
+			(m[1][1]*m[2][2]*m[3][3] + m[2][1]*m[3][2]*m[1][3] + m[3][1]*m[1][2]*m[2][3] - m[3][1]*m[2][2]*m[1][3] - m[2][1]*m[1][2]*m[3][3] - m[1][1]*m[3][2]*m[2][3]) / d,
 			-(m[0][1]*m[2][2]*m[3][3] + m[2][1]*m[3][2]*m[0][3] + m[3][1]*m[0][2]*m[2][3] - m[3][1]*m[2][2]*m[0][3] - m[2][1]*m[0][2]*m[3][3] - m[0][1]*m[3][2]*m[2][3]) / d,
 			(m[0][1]*m[1][2]*m[3][3] + m[1][1]*m[3][2]*m[0][3] + m[3][1]*m[0][2]*m[1][3] - m[3][1]*m[1][2]*m[0][3] - m[1][1]*m[0][2]*m[3][3] - m[0][1]*m[3][2]*m[1][3]) / d,
 			-(m[0][1]*m[1][2]*m[2][3] + m[1][1]*m[2][2]*m[0][3] + m[2][1]*m[0][2]*m[1][3] - m[2][1]*m[1][2]*m[0][3] - m[1][1]*m[0][2]*m[2][3] - m[0][1]*m[2][2]*m[1][3]) / d,
@@ -155,3 +159,67 @@ func (m Mat) Inverse() Mat {
 		},
 	}
 }
+
+// InverseChecked returns the inverse matrix of m, or ErrSingularMatrix if the absolute value of m's determinant is not
+// greater than epsilon. [Mat.Inverse] divides by the determinant unconditionally, so a singular matrix (e.g. a scale
+// of zero on any axis) produces a matrix of Inf and NaN values. Use this function when m comes from an untrusted or
+// user-controlled source.
+//
+// Note that the determinant scales with the cube of any scale factor in m, so choose epsilon relative to the smallest
+// scale you expect to invert.
+func (m Mat) InverseChecked(epsilon float32) (Mat, error) {
+	d := m.Determinant()
+	if !(math32.Abs(d) > epsilon) { // Also catches NaN
+		return Mat{}, ErrSingularMatrix
+	}
+	return m.Inverse(), nil
+}
+
+// InverseAffine returns the inverse of m, assuming that m is an affine transformation (i.e. any combination of
+// translation, rotation, scale and shear, with a bottom row of {0, 0, 0, 1}). This is considerably cheaper than the
+// general [Mat.Inverse], as only the upper 3x3 needs to be inverted. The result is undefined if m is a projection
+// matrix.
+func (m Mat) InverseAffine() Mat {
+	// Rows of the inverse 3x3 are the cross products of pairs of columns, divided by the determinant
+	r00 := m[1][1]*m[2][2] - m[1][2]*m[2][1]
+	r01 := m[1][2]*m[2][0] - m[1][0]*m[2][2]
+	r02 := m[1][0]*m[2][1] - m[1][1]*m[2][0]
+	d := 1 / (m[0][0]*r00 + m[0][1]*r01 + m[0][2]*r02)
+
+	r00, r01, r02 = r00*d, r01*d, r02*d
+	r10 := (m[2][1]*m[0][2] - m[2][2]*m[0][1]) * d
+	r11 := (m[2][2]*m[0][0] - m[2][0]*m[0][2]) * d
+	r12 := (m[2][0]*m[0][1] - m[2][1]*m[0][0]) * d
+	r20 := (m[0][1]*m[1][2] - m[0][2]*m[1][1]) * d
+	r21 := (m[0][2]*m[1][0] - m[0][0]*m[1][2]) * d
+	r22 := (m[0][0]*m[1][1] - m[0][1]*m[1][0]) * d
+
+	return Mat{
+		{r00, r10, r20, 0},
+		{r01, r11, r21, 0},
+		{r02, r12, r22, 0},
+		{
+			-(r00*m[3][0] + r01*m[3][1] + r02*m[3][2]),
+			-(r10*m[3][0] + r11*m[3][1] + r12*m[3][2]),
+			-(r20*m[3][0] + r21*m[3][1] + r22*m[3][2]),
+			1,
+		},
+	}
+}
+
+// InverseRigid returns the inverse of m, assuming that m is a rigid body transformation consisting only of rotation and
+// translation, such as a camera or view matrix. The rotation is inverted by transposing it, so the result is incorrect
+// if m contains any scale or shear; use [Mat.InverseAffine] instead in that case.
+func (m Mat) InverseRigid() Mat {
+	// -R^T * t, where each row of R^T is a column of m
+	tx := -(m[0][0]*m[3][0] + m[0][1]*m[3][1] + m[0][2]*m[3][2])
+	ty := -(m[1][0]*m[3][0] + m[1][1]*m[3][1] + m[1][2]*m[3][2])
+	tz := -(m[2][0]*m[3][0] + m[2][1]*m[3][1] + m[2][2]*m[3][2])
+
+	return Mat{
+		{m[0][0], m[1][0], m[2][0], 0},
+		{m[0][1], m[1][1], m[2][1], 0},
+		{m[0][2], m[1][2], m[2][2], 0},
+		{tx, ty, tz, 1},
+	}
+}
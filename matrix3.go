@@ -0,0 +1,222 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Mat3 is a column-major 3x3 matrix of float32s. As with Mat, elements can be directly addressed via double brackets:
+// m[col][row]
+//
+// Mat3 serves two purposes: it holds the rotation and scale portion of a Mat, e.g. the normal matrix returned by
+// [Mat.NormalMatrix], and it is a homogenous transformation for 2D points and vectors. See [Mat3.MultP2] and
+// [Mat3.MultV2].
+//
+// Note that GLSL's std140 and std430 layouts pad each column of a mat3 to four floats, so a Mat3 cannot be uploaded to
+// a uniform buffer byte-for-byte. Convert with [Mat3.Homogenize] first, or pad the columns yourself.
+type Mat3 [3]Vec3
+
+// Identity3 returns a 3x3 identity matrix
+func Identity3() Mat3 {
+	return Mat3{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+// UpperLeft3 returns the upper-left 3x3 portion of m, which holds the rotation, scale and shear of an affine
+// transformation.
+func (m Mat) UpperLeft3() Mat3 {
+	return Mat3{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+	}
+}
+
+// NormalMatrix returns the inverse-transpose of the upper-left 3x3 of m. Surface normals must be transformed by this
+// matrix rather than by m itself, or they will no longer be perpendicular to the surface after a non-uniform scale.
+func (m Mat) NormalMatrix() Mat3 {
+	return m.UpperLeft3().Inverse().Transpose()
+}
+
+// Homogenize converts m into a 4x4 matrix, placing m in the upper-left corner and filling the remainder from the
+// identity matrix.
+func (m Mat3) Homogenize() Mat {
+	return Mat{
+		{m[0][0], m[0][1], m[0][2], 0},
+		{m[1][0], m[1][1], m[1][2], 0},
+		{m[2][0], m[2][1], m[2][2], 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// MultV computes a matrix multiplication on the provided vector
+func (m Mat3) MultV(v Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*v[0] + m[1][0]*v[1] + m[2][0]*v[2],
+		m[0][1]*v[0] + m[1][1]*v[1] + m[2][1]*v[2],
+		m[0][2]*v[0] + m[1][2]*v[1] + m[2][2]*v[2],
+	}
+}
+
+// MultP2 transforms a 2D point, treating m as a homogenous 2D transformation. The point is extended with w = 1, so
+// translation is applied, and the result is divided by the resulting w component.
+func (m Mat3) MultP2(p Pt2) Pt2 {
+	r := m.MultV(Vec3{p[0], p[1], 1})
+	return Pt2{r[0] / r[2], r[1] / r[2]}
+}
+
+// MultV2 transforms a 2D vector, treating m as a homogenous 2D transformation. The vector is extended with w = 0, so
+// translation is not applied.
+func (m Mat3) MultV2(v Vec2) Vec2 {
+	r := m.MultV(Vec3{v[0], v[1], 0})
+	return Vec2{r[0], r[1]}
+}
+
+// MultM performs a matrix multiplication.
+func (m Mat3) MultM(n Mat3) Mat3 {
+	return Mat3{
+		m.MultV(n[0]),
+		m.MultV(n[1]),
+		m.MultV(n[2]),
+	}
+}
+
+// Equals returns true if m and n are exactly equal. See [Mat.Equals].
+func (m Mat3) Equals(n Mat3) bool {
+	return m == n
+}
+
+// ApproximatelyEquals returns true if m and n are equal component-for-component to within `precision`.
+func (m Mat3) ApproximatelyEquals(n Mat3, precision float32) bool {
+	for i := range m {
+		for j := range m[i] {
+			if math32.Abs(m[i][j]-n[i][j]) > precision {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Transpose returns the transpose matrix of m.
+func (m Mat3) Transpose() Mat3 {
+	return Mat3{
+		{m[0][0], m[1][0], m[2][0]},
+		{m[0][1], m[1][1], m[2][1]},
+		{m[0][2], m[1][2], m[2][2]},
+	}
+}
+
+// Determinant returns the determinant of m.
+func (m Mat3) Determinant() float32 {
+	return m[0].Dot(m[1].Cross(m[2]))
+}
+
+// Inverse returns the inverse matrix of m, i.e. the matrix such that m.MultM(m.Inverse()) yields the identity matrix.
+func (m Mat3) Inverse() Mat3 {
+	// The rows of the inverse are the cross products of pairs of columns, divided by the determinant.
+	r0 := m[1].Cross(m[2])
+	r1 := m[2].Cross(m[0])
+	r2 := m[0].Cross(m[1])
+	d := m[0].Dot(r0)
+
+	return Mat3{
+		{r0[0] / d, r1[0] / d, r2[0] / d},
+		{r0[1] / d, r1[1] / d, r2[1] / d},
+		{r0[2] / d, r1[2] / d, r2[2] / d},
+	}
+}
+
+// symmetricEigen computes the eigenvalues and eigenvectors of the symmetric matrix m with the cyclic Jacobi method.
+// The eigenvectors are returned as the (unit length) columns of the matrix, in the same order as the eigenvalues.
+func (m Mat3) symmetricEigen() (Vec3, Mat3) {
+	a, v := m, Identity3()
+	for sweep := 0; sweep < 16; sweep++ {
+		if a[0][1]*a[0][1]+a[0][2]*a[0][2]+a[1][2]*a[1][2] < 1e-20 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				// Rotate in the p-q plane by the angle that zeroes a[p][q]
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math32.Abs(theta) + math32.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math32.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				vp, vq := v[p], v[q]
+				v[p], v[q] = vp.Scale(c).Sub(vq.Scale(s)), vp.Scale(s).Add(vq.Scale(c))
+			}
+		}
+	}
+	return Vec3{a[0][0], a[1][1], a[2][2]}, v
+}
+
+/*** 2D Transformations ***/
+
+// NewMat3Translate generates a 2D translation matrix using v.
+func NewMat3Translate(v Vec2) Mat3 {
+	rval := Identity3()
+	rval[2][0] = v[0]
+	rval[2][1] = v[1]
+	return rval
+}
+
+// Translate applies a 2D translation of v to m and returns the resulting matrix.
+func (m Mat3) Translate(v Vec2) Mat3 {
+	return NewMat3Translate(v).MultM(m)
+}
+
+// NewMat3Scale creates a 2D scaling matrix using v. A negative value on either axis will create a reflection across
+// that axis.
+func NewMat3Scale(v Vec2) Mat3 {
+	rval := Identity3()
+	rval[0][0] = v[0]
+	rval[1][1] = v[1]
+	return rval
+}
+
+// Scale applies a 2D scale transformation to m and returns the result.
+func (m Mat3) Scale(v Vec2) Mat3 {
+	return NewMat3Scale(v).MultM(m)
+}
+
+// NewMat3Rotate generates a 2D CCW rotation around the origin by theta radians.
+func NewMat3Rotate(theta float32) Mat3 {
+	ct := math32.Cos(theta)
+	st := math32.Sin(theta)
+
+	return Mat3{
+		{ct, st, 0},
+		{-st, ct, 0},
+		{0, 0, 1},
+	}
+}
+
+// NewMat3RotateDeg generates a 2D CCW rotation around the origin by deg degrees.
+func NewMat3RotateDeg(deg float32) Mat3 {
+	return NewMat3Rotate(2 * math32.Pi * deg / 360.0)
+}
+
+// Rotate applies a 2D rotation around the origin to m and returns the resulting matrix.
+func (m Mat3) Rotate(theta float32) Mat3 {
+	return NewMat3Rotate(theta).MultM(m)
+}
+
+// RotateDeg applies a 2D rotation around the origin to m and returns the resulting matrix.
+func (m Mat3) RotateDeg(deg float32) Mat3 {
+	return NewMat3RotateDeg(deg).MultM(m)
+}
@@ -0,0 +1,72 @@
+package vkm
+
+import "testing"
+
+func TestMat3Inverse(t *testing.T) {
+	m := Mat3{
+		{2, 0, 1},
+		{1, 3, 0},
+		{0, 1, 4},
+	}
+	if d := m.Determinant(); d != 25 {
+		t.Errorf("Determinant failed! Expected: %v Actual: %v", 25, d)
+	}
+
+	res := m.MultM(m.Inverse())
+	if !res.ApproximatelyEquals(Identity3(), 0.00001) {
+		t.Errorf("m x m.Inverse() was not the identity! Actual: %+v", res)
+	}
+
+	exp := NewMatRotate(NewVec(1, 2, 3).Normalize(), 0.7).Scale(NewVec(2, 3, 4))
+	m4 := exp.UpperLeft3().Inverse().Homogenize()
+	if !m4.ApproximatelyEquals(exp.Inverse(), 0.00001) {
+		t.Errorf("Mat3 inverse did not match Mat inverse! Expected: %+v Actual: %+v", exp.Inverse(), m4)
+	}
+}
+
+func TestNormalMatrix(t *testing.T) {
+	// A surface tilted 45 degrees in the XY plane, then squashed along Y
+	m := NewMatScale(NewVec(1, 0.5, 1)).Translate(NewVec(5, 5, 5))
+	tangent := NewVec(1, -1, 0)
+	normal := NewVec(1, 1, 0)
+
+	tTangent := m.MultV(tangent)
+	n := m.NormalMatrix().MultV(Vec3{normal[0], normal[1], normal[2]}).Homogenize()
+
+	if d := tTangent.Dot(n); d > 0.00001 || d < -0.00001 {
+		t.Errorf("Transformed normal is not perpendicular to the surface! Dot product: %v", d)
+	}
+}
+
+func TestMat3Transform2D(t *testing.T) {
+	m := Identity3().
+		Translate(Vec2{-1, 0}).
+		RotateDeg(90).
+		Scale(Vec2{2, 2})
+
+	exp := Pt2{0, 2}
+	if res := m.MultP2(Pt2{2, 0}); !(Vec2(res).Sub(Vec2(exp)).Length() < 0.00001) {
+		t.Errorf("2D point transform failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	expV := Vec2{0, 2}
+	if res := m.MultV2(Vec2{1, 0}); !(res.Sub(expV).Length() < 0.00001) {
+		t.Errorf("2D vector transform failed! Expected: %+v Actual: %+v", expV, res)
+	}
+}
+
+func TestMat3SymmetricEigen(t *testing.T) {
+	m := Mat3{
+		{4, 1, -2},
+		{1, 2, 0},
+		{-2, 0, 3},
+	}
+	values, vectors := m.symmetricEigen()
+	for i := range vectors {
+		exp := vectors[i].Scale(values[i])
+		res := m.MultV(vectors[i])
+		if !Pt(res.Homogenize()).EqualTo(Pt(exp.Homogenize())) {
+			t.Errorf("Eigenvector %d failed! Expected: %+v Actual: %+v", i, exp, res)
+		}
+	}
+}
@@ -117,4 +117,84 @@ func TestInverse(t *testing.T) {
 		t.Errorf("Inverse on test 2 failed! Expected: %+v Actual: %+v", ex1, m1Inv)
 	}
 
+	// No zero or symmetric elements, so that every cofactor term contributes
+	m2 := Mat{
+		{1, 2, 3, 4},
+		{5, 7, 1, 2},
+		{3, 1, 4, 1},
+		{2, 6, 1, 3},
+	}
+	if res := m2.MultM(m2.Inverse()); !res.ApproximatelyEquals(mId, 0.0001) {
+		t.Errorf("Inverse on test 3 failed! Expected identity, actual: %+v", res)
+	}
+
+}
+
+func TestInverseChecked(t *testing.T) {
+	m := NewMatScale(NewVec(2, 0, 1))
+	if _, err := m.InverseChecked(0.000001); err != ErrSingularMatrix {
+		t.Errorf("InverseChecked on a zero scale matrix did not return ErrSingularMatrix! Actual: %v", err)
+	}
+
+	m = NewMatScale(NewVec(0.01, 0.01, 0.01))
+	if _, err := m.InverseChecked(0.0001); err != ErrSingularMatrix {
+		t.Errorf("InverseChecked did not respect epsilon! Actual: %v", err)
+	}
+
+	m = NewMatTranslate(NewVec(1, 2, 3)).Scale(NewVec(2, 2, 2))
+	inv, err := m.InverseChecked(0.000001)
+	if err != nil {
+		t.Errorf("InverseChecked on an invertible matrix returned an error: %v", err)
+	}
+	if !inv.ApproximatelyEquals(m.Inverse(), 0.00001) {
+		t.Errorf("InverseChecked did not match Inverse! Expected: %+v Actual: %+v", m.Inverse(), inv)
+	}
+}
+
+func TestInverseAffine(t *testing.T) {
+	m := Identity().
+		Scale(NewVec(2, 3, 0.5)).
+		RotateDeg(NewVec(1, 1, 0).Normalize(), 30).
+		Translate(NewVec(4, -5, 6))
+
+	exp := m.Inverse()
+	res := m.InverseAffine()
+	if !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("InverseAffine did not match Inverse! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestInverseRigid(t *testing.T) {
+	m := Identity().
+		RotateDeg(NewVec(1, 2, 3).Normalize(), 75).
+		Translate(NewVec(4, -5, 6))
+
+	exp := m.Inverse()
+	res := m.InverseRigid()
+	if !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("InverseRigid did not match Inverse! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+var benchMat Mat
+
+func BenchmarkInverse(b *testing.B) {
+	m := Identity().RotateDeg(NewVec(1, 2, 3).Normalize(), 75).Translate(NewVec(4, -5, 6))
+	for i := 0; i < b.N; i++ {
+		benchMat = m.Inverse()
+	}
+}
+
+func BenchmarkInverseAffine(b *testing.B) {
+	m := Identity().RotateDeg(NewVec(1, 2, 3).Normalize(), 75).Translate(NewVec(4, -5, 6))
+	for i := 0; i < b.N; i++ {
+		benchMat = m.InverseAffine()
+	}
+}
+
+func BenchmarkInverseRigid(b *testing.B) {
+	m := Identity().RotateDeg(NewVec(1, 2, 3).Normalize(), 75).Translate(NewVec(4, -5, 6))
+	for i := 0; i < b.N; i++ {
+		benchMat = m.InverseRigid()
+	}
 }
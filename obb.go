@@ -0,0 +1,214 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// OBB is an oriented bounding box: a box centered on Center, with edges along the three Axes. Axes must be
+// orthonormal, and HalfExtents holds the distance from the center to each face along the corresponding axis.
+type OBB struct {
+	Center      Pt
+	Axes        [3]Vec
+	HalfExtents Vec
+}
+
+// NewOBBFromAABB returns the oriented box equivalent to b, aligned to the world axes.
+func NewOBBFromAABB(b AABB) OBB {
+	return OBB{b.Center(), [3]Vec{UnitVecX(), UnitVecY(), UnitVecZ()}, b.Extents()}
+}
+
+// NewOBBFromPoints fits an oriented box to pts. The axes are the principal components of the points (the eigenvectors
+// of their covariance matrix), and the box is sized to contain every point. The fit is usually tight for long, thin
+// objects, but it depends on how the points are distributed: a dense cluster of vertices pulls the axes towards it. If
+// pts is empty, the result is a zero-size box at the origin.
+//
+// The axes are ordered by decreasing spread of the points: Axes[0] is the direction of greatest variance and Axes[2]
+// the least, so HalfExtents is usually, though not always, in descending order. Axes[2] is chosen to make the basis
+// right-handed.
+func NewOBBFromPoints(pts []Pt) OBB {
+	rval := OBB{Origin(), [3]Vec{UnitVecX(), UnitVecY(), UnitVecZ()}, ZeroVec()}
+	if len(pts) == 0 {
+		return rval
+	}
+
+	var mean Vec
+	for _, p := range pts {
+		mean = mean.Add(Vec(p))
+	}
+	mean = mean.Scale(1 / float32(len(pts)))
+
+	var cov Mat3
+	for _, p := range pts {
+		d := Vec(p).Sub(mean)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				cov[i][j] += d[i] * d[j]
+			}
+		}
+	}
+	values, vectors := cov.symmetricEigen()
+	// Order the axes by descending variance, so Axes[0] is the principal axis
+	order := [3]int{0, 1, 2}
+	for i := 1; i < 3; i++ {
+		for j := i; j > 0 && values[order[j]] > values[order[j-1]]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+	for i, k := range order {
+		rval.Axes[i] = vectors[k].Homogenize().Normalize()
+	}
+	// Ensure a right-handed basis, after sorting
+	rval.Axes[2] = rval.Axes[0].Cross(rval.Axes[1])
+
+	// Project the points onto each axis to find the extents, then re-center the box between them
+	min, max := Vec{}, Vec{}
+	for i := 0; i < 3; i++ {
+		min[i], max[i] = math32.Inf(1), math32.Inf(-1)
+	}
+	for _, p := range pts {
+		for i, a := range rval.Axes {
+			d := a.Dot(Vec(p))
+			min[i] = math32.Min(min[i], d)
+			max[i] = math32.Max(max[i], d)
+		}
+	}
+	for i, a := range rval.Axes {
+		rval.Center = rval.Center.Add(a.Scale((min[i] + max[i]) / 2))
+		rval.HalfExtents[i] = (max[i] - min[i]) / 2
+	}
+	return rval
+}
+
+// Corners returns the eight corners of o.
+func (o OBB) Corners() [8]Pt {
+	var rval [8]Pt
+	for i := range rval {
+		p := o.Center
+		for j, a := range o.Axes {
+			e := o.HalfExtents[j]
+			if i&(1<<j) == 0 {
+				e = -e
+			}
+			p = p.Add(a.Scale(e))
+		}
+		rval[i] = p
+	}
+	return rval
+}
+
+// local returns the coordinates of p in o's frame, relative to its center.
+func (o OBB) local(p Pt) Vec {
+	d := o.Center.VecTo(p)
+	return NewVec(o.Axes[0].Dot(d), o.Axes[1].Dot(d), o.Axes[2].Dot(d))
+}
+
+// ContainsPt returns true if p is inside o or on its boundary.
+func (o OBB) ContainsPt(p Pt) bool {
+	l := o.local(p)
+	for i := 0; i < 3; i++ {
+		if math32.Abs(l[i]) > o.HalfExtents[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectRay tests r against o, using the slab method in o's local frame. If the ray hits the box, IntersectRay
+// returns the distance along the ray to the first intersection (zero if the ray starts inside the box) and true.
+func (o OBB) IntersectRay(r Ray) (float32, bool) {
+	local := Ray{Pt(o.local(r.Origin)), NewVec(o.Axes[0].Dot(r.Direction), o.Axes[1].Dot(r.Direction), o.Axes[2].Dot(r.Direction))}
+	local.Origin[3] = 1
+	e := o.HalfExtents
+	return AABB{Pt{-e[0], -e[1], -e[2], 1}, Pt{e[0], e[1], e[2], 1}}.IntersectRay(local)
+}
+
+// Intersects returns true if o and p overlap or touch, using the separating axis theorem. This follows Christer
+// Ericson's Real-Time Collision Detection, section 4.4.1.
+func (o OBB) Intersects(p OBB) bool {
+	// r expresses p's axes in o's frame, and t is p's center in o's frame
+	var r, absR Mat3
+	const eps = 0.000001
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = o.Axes[i].Dot(p.Axes[j])
+			// The epsilon guards against a false separation when edges are (nearly) parallel and their cross product
+			// is close to zero.
+			absR[i][j] = math32.Abs(r[i][j]) + eps
+		}
+	}
+	t := o.local(p.Center)
+	a, b := o.HalfExtents, p.HalfExtents
+
+	// o's face normals
+	for i := 0; i < 3; i++ {
+		if math32.Abs(t[i]) > a[i]+b[0]*absR[i][0]+b[1]*absR[i][1]+b[2]*absR[i][2] {
+			return false
+		}
+	}
+	// p's face normals
+	for j := 0; j < 3; j++ {
+		ra := a[0]*absR[0][j] + a[1]*absR[1][j] + a[2]*absR[2][j]
+		if math32.Abs(t[0]*r[0][j]+t[1]*r[1][j]+t[2]*r[2][j]) > ra+b[j] {
+			return false
+		}
+	}
+	// The nine cross products of edge directions
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := a[i1]*absR[i2][j] + a[i2]*absR[i1][j]
+			rb := b[j1]*absR[i][j2] + b[j2]*absR[i][j1]
+			if math32.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IntersectsAABB returns true if o and b overlap or touch.
+func (o OBB) IntersectsAABB(b AABB) bool {
+	return o.Intersects(NewOBBFromAABB(b))
+}
+
+// AABB returns the smallest axis-aligned box containing o.
+func (o OBB) AABB() AABB {
+	var e Vec
+	for i, a := range o.Axes {
+		e = e.Add(absVec(a).Scale(o.HalfExtents[i]))
+	}
+	return AABB{o.Center.Add(e.Invert()), o.Center.Add(e)}
+}
+
+// TransformOBB returns o transformed by m, which is assumed to be affine. Rotation, translation and scale (including
+// non-uniform scale aligned with o's axes) are exact. If m skews the box, the transformed shape is a parallelepiped and
+// the result is an oriented box that contains it.
+func (m Mat) TransformOBB(o OBB) OBB {
+	// The transformed edge vectors from the center to each face
+	var edges [3]Vec
+	for i, a := range o.Axes {
+		edges[i] = m.MultV(a.Scale(o.HalfExtents[i]))
+	}
+
+	// Orthonormalize the edges to find the new axes, then size the box to enclose all of the transformed edges
+	rval := OBB{Center: m.MultP(o.Center)}
+	rval.Axes[0] = orthoAxis(edges[0], UnitVecX())
+	rval.Axes[1] = orthoAxis(edges[1].Sub(rval.Axes[0].Scale(rval.Axes[0].Dot(edges[1]))), rval.Axes[0].Cross(UnitVecZ()))
+	rval.Axes[2] = rval.Axes[0].Cross(rval.Axes[1])
+	for i, a := range rval.Axes {
+		for _, e := range edges {
+			rval.HalfExtents[i] += math32.Abs(a.Dot(e))
+		}
+	}
+	return rval
+}
+
+// orthoAxis normalizes v, or falls back to the normalized fallback vector if v is too short to have a direction.
+func orthoAxis(v, fallback Vec) Vec {
+	if v.SquareLength() < 1e-12 {
+		if fallback.SquareLength() < 1e-12 {
+			fallback = UnitVecY()
+		}
+		return fallback.Normalize()
+	}
+	return v.Normalize()
+}
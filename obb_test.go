@@ -0,0 +1,152 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestNewOBBFromPoints(t *testing.T) {
+	// Long, thin boxes running diagonally through space, with the long side along a different source axis in each case
+	// so that the fit cannot rely on the order of the eigenvectors
+	tests := []struct {
+		m    Mat
+		size Vec // half extents of the source box
+		long int // index of the long side
+	}{
+		{NewMatRotateZDeg(30).RotateXDeg(20).Translate(NewVec(1, 2, 3)), NewVec(5, 0.5, 0.25), 0},
+		{NewMatRotateYDeg(35).RotateZDeg(70).Translate(NewVec(1, 2, 3)), NewVec(0.25, 5, 0.5), 1},
+		{NewMatRotateXDeg(-50).RotateYDeg(15).Translate(NewVec(1, 2, 3)), NewVec(0.5, 0.25, 5), 2},
+	}
+
+	for _, test := range tests {
+		// Points along the long edges of the box, one unit apart
+		var pts []Pt
+		a, b := (test.long+1)%3, (test.long+2)%3
+		for s := -test.size[test.long]; s <= test.size[test.long]; s++ {
+			for _, u := range []float32{-1, 1} {
+				for _, v := range []float32{-1, 1} {
+					l := Origin()
+					l[test.long], l[a], l[b] = s, u*test.size[a], v*test.size[b]
+					pts = append(pts, test.m.MultP(l))
+				}
+			}
+		}
+		longAxis := Vec{}
+		longAxis[test.long] = 1
+
+		o := NewOBBFromPoints(pts)
+		exp := NewVec(5, 0.5, 0.25)
+		if !Pt(o.HalfExtents).EqualTo(Pt(exp)) {
+			t.Errorf("Fitted OBB extents did not match the source box! Expected: %+v Actual: %+v", exp, o.HalfExtents)
+		}
+		if !o.Center.EqualTo(NewPt(1, 2, 3)) {
+			t.Errorf("Fitted OBB center failed! Expected: %+v Actual: %+v", NewPt(1, 2, 3), o.Center)
+		}
+		if d := math32.Abs(o.Axes[0].Dot(test.m.MultV(longAxis))); math32.Abs(d-1) > 0.0001 {
+			t.Errorf("Fitted OBB's major axis was not along the box! Expected: %+v Actual: %+v", test.m.MultV(longAxis), o.Axes[0])
+		}
+		if d := o.Axes[0].Cross(o.Axes[1]).Dot(o.Axes[2]); math32.Abs(d-1) > 0.0001 {
+			t.Errorf("Fitted OBB axes were not a right-handed orthonormal basis! Actual: %+v", o.Axes)
+		}
+
+		// Every point must be inside, allowing for rounding on the faces
+		loose := o
+		loose.HalfExtents = o.HalfExtents.Add(NewVec(0.0001, 0.0001, 0.0001))
+		for _, p := range pts {
+			if !loose.ContainsPt(p) {
+				t.Errorf("Fitted OBB %+v did not contain %+v!", o, p)
+			}
+		}
+	}
+}
+
+func TestOBBIntersects(t *testing.T) {
+	a := NewOBBFromAABB(NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1)))
+
+	// A unit cube rotated 45 degrees around Z reaches sqrt(2) along X
+	rot := NewMatRotateZDeg(45).TransformOBB(a)
+	b := rot
+	b.Center = NewPt(2.3, 0, 0)
+	if !a.Intersects(b) || !b.Intersects(a) {
+		t.Errorf("Rotated box reaching into the cube did not intersect!")
+	}
+	b.Center = NewPt(2.5, 0, 0)
+	if a.Intersects(b) || b.Intersects(a) {
+		t.Errorf("Rotated box beyond the cube intersected!")
+	}
+
+	// Boxes rotated in different planes
+	c := NewMatRotateXDeg(45).TransformOBB(a)
+	d := NewMatRotateYDeg(45).TransformOBB(a)
+	d.Center = NewPt(0, 2.1, 2.1)
+	if c.Intersects(d) {
+		t.Errorf("Separate boxes rotated in different planes intersected!")
+	}
+	d.Center = NewPt(0, 1.6, 1.6)
+	if !c.Intersects(d) {
+		t.Errorf("Overlapping boxes rotated in different planes did not intersect!")
+	}
+
+	if !rot.IntersectsAABB(NewAABB(NewPt(1.3, -0.1, -0.1), NewPt(2, 0.1, 0.1))) {
+		t.Errorf("OBB-AABB intersection failed!")
+	}
+	if rot.IntersectsAABB(NewAABB(NewPt(1, 1, -1), NewPt(2, 2, 1))) {
+		t.Errorf("AABB in the rotated box's corner gap intersected!")
+	}
+}
+
+func TestOBBIntersectRay(t *testing.T) {
+	o := NewMatRotateZDeg(45).TransformOBB(NewOBBFromAABB(NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1))))
+
+	if d, ok := o.IntersectRay(NewRay(NewPt(-5, 0, 0), UnitVecX())); !ok || math32.Abs(d-(5-math32.Sqrt2)) > 0.0001 {
+		t.Errorf("Ray toward the box corner failed! Expected: %v, true Actual: %v, %v", 5-math32.Sqrt2, d, ok)
+	}
+	if _, ok := o.IntersectRay(NewRay(NewPt(-5, 1.45, 0), UnitVecX())); ok {
+		t.Errorf("Ray passing the box corner hit!")
+	}
+	if d, ok := o.IntersectRay(NewRay(Origin(), UnitVecY())); !ok || d != 0 {
+		t.Errorf("Ray from inside the box failed! Expected: 0, true Actual: %v, %v", d, ok)
+	}
+}
+
+func TestTransformOBB(t *testing.T) {
+	o := NewOBBFromAABB(NewAABB(NewPt(-1, -2, -3), NewPt(1, 2, 3)))
+	m := NewMatScale(NewVec(2, 1, 0.5)).RotateYDeg(30).Translate(NewVec(4, 5, 6))
+
+	res := m.TransformOBB(o)
+	if !Pt(res.HalfExtents).EqualTo(Pt(NewVec(2, 2, 1.5))) {
+		t.Errorf("TransformOBB extents failed! Expected: %+v Actual: %+v", NewVec(2, 2, 1.5), res.HalfExtents)
+	}
+
+	// Every transformed corner must be a corner of the result
+	resCorners := res.Corners()
+	for _, c := range o.Corners() {
+		p := m.MultP(c)
+		found := false
+		for _, rc := range resCorners {
+			found = found || rc.EqualTo(p)
+		}
+		if !found {
+			t.Errorf("Transformed corner %+v was not a corner of %+v!", p, res)
+		}
+	}
+
+	// A shear must produce a box that still contains the transformed corners
+	sh := Mat{{1, 0, 0, 0}, {1, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	res = sh.TransformOBB(o)
+	res.HalfExtents = res.HalfExtents.Add(NewVec(0.0001, 0.0001, 0.0001))
+	for _, c := range o.Corners() {
+		if p := sh.MultP(c); !res.ContainsPt(p) {
+			t.Errorf("Sheared corner %+v was outside %+v!", p, res)
+		}
+	}
+}
+
+func TestOBBAABB(t *testing.T) {
+	o := NewMatRotateZDeg(45).TransformOBB(NewOBBFromAABB(NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1))))
+	exp := NewAABB(NewPt(-math32.Sqrt2, -math32.Sqrt2, -1), NewPt(math32.Sqrt2, math32.Sqrt2, 1))
+	if b := o.AABB(); !b.Min.EqualTo(exp.Min) || !b.Max.EqualTo(exp.Max) {
+		t.Errorf("OBB.AABB failed! Expected: %+v Actual: %+v", exp, b)
+	}
+}
@@ -0,0 +1,212 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// OrbitCamera is a camera controller that circles a target point, as used by model viewers and editors. The eye sits
+// Distance units from Target, in the direction given by Yaw and Pitch (in radians) around the Up axis. With Yaw and
+// Pitch both zero, the camera looks at the target along the reference forward axis described in [OrbitCamera.Eye].
+//
+// The exported fields hold the current state of the camera and may be set directly. The Rotate, Pan, Zoom, Dolly and
+// Arcball operations queue their motion, which is applied to the fields immediately if Smoothing is zero, or eased in
+// over subsequent calls to [OrbitCamera.Update] otherwise.
+//
+// The zero value is not usable; create an OrbitCamera with [NewOrbitCamera].
+type OrbitCamera struct {
+	Target   Pt
+	Distance float32
+	Yaw      float32
+	Pitch    float32
+	Up       Vec
+
+	// MinPitch and MaxPitch limit Pitch. The defaults stop just short of looking straight up or down, where the view
+	// matrix would be undefined.
+	MinPitch, MaxPitch float32
+	// MinDistance and MaxDistance limit Distance.
+	MinDistance, MaxDistance float32
+
+	// Smoothing is the half-life, in seconds, of queued motion: after Smoothing seconds of updates, half of any pending
+	// rotation, pan or zoom has been applied. Zero disables damping.
+	Smoothing float32
+
+	pendingYaw, pendingPitch float32
+	pendingPan               Vec
+	pendingZoom              float32 // natural log of the pending distance scale factor
+	pendingDolly             float32
+}
+
+// NewOrbitCamera creates an orbit camera looking at target from distance units away, with pitch limited to just short
+// of straight up and down, and no damping.
+func NewOrbitCamera(target Pt, distance float32, up Vec) *OrbitCamera {
+	const maxPitch = math32.Pi/2 - 0.001
+	return &OrbitCamera{
+		Target:      target,
+		Distance:    distance,
+		Up:          up,
+		MinPitch:    -maxPitch,
+		MaxPitch:    maxPitch,
+		MinDistance: 0.001,
+		MaxDistance: math32.MaxFloat32,
+	}
+}
+
+// orbitBasis returns an orthonormal basis around up: the normalized up axis, a reference forward axis perpendicular to
+// it, and a right axis completing the basis.
+func orbitBasis(up Vec) (u, f, r Vec) {
+	u = up.Normalize()
+	// Prefer +Z as the reference, falling back to +X when up is (close to) the Z axis
+	ref := UnitVecZ()
+	if math32.Abs(u[2]) > 0.9 {
+		ref = UnitVecX()
+	}
+	f = ref.Sub(u.Scale(u.Dot(ref))).Normalize()
+	r = u.Cross(f)
+	return
+}
+
+// direction returns the unit vector from the target toward the eye for the given yaw and pitch.
+func (c *OrbitCamera) direction(yaw, pitch float32) Vec {
+	return yawPitchDirection(c.Up, yaw, pitch)
+}
+
+// yawPitchDirection returns the unit vector at yaw radians counter-clockwise around up from the reference forward axis
+// of [orbitBasis], raised pitch radians toward up.
+func yawPitchDirection(up Vec, yaw, pitch float32) Vec {
+	u, f, r := orbitBasis(up)
+	cp := math32.Cos(pitch)
+	return f.Scale(cp * math32.Cos(yaw)).
+		Add(r.Scale(cp * math32.Sin(yaw))).
+		Add(u.Scale(math32.Sin(pitch)))
+}
+
+// Eye returns the current location of the camera. At zero yaw and pitch, the eye lies along the reference forward
+// axis from the target: +Z for any up vector that is not close to the Z axis, or +X otherwise. Positive yaw rotates the
+// eye counter-clockwise around Up, and positive pitch raises it above the target.
+func (c *OrbitCamera) Eye() Pt {
+	return c.Target.Add(c.direction(c.Yaw, c.Pitch).Scale(c.Distance))
+}
+
+// View returns the view matrix for the camera's current state, built with [Camera].
+func (c *OrbitCamera) View() Mat {
+	eye := c.Eye()
+	return Camera(eye, eye.VecTo(c.Target), c.Up)
+}
+
+// Rotate orbits the camera around the target by dYaw and dPitch radians. Pitch is clamped to [MinPitch..MaxPitch].
+func (c *OrbitCamera) Rotate(dYaw, dPitch float32) {
+	goal := clamp(c.Pitch+c.pendingPitch+dPitch, c.MinPitch, c.MaxPitch)
+	c.pendingPitch = goal - c.Pitch
+	c.pendingYaw += dYaw
+	c.applyNow()
+}
+
+// Pan moves both the camera and the target by dx units along the view's right axis and dy units along its up axis.
+// To keep the scene moving with the cursor, scale screen space deltas by Distance.
+func (c *OrbitCamera) Pan(dx, dy float32) {
+	back := c.direction(c.Yaw, c.Pitch)
+	right := back.Invert().Cross(c.Up).Normalize()
+	up := back.Cross(right)
+	c.pendingPan = c.pendingPan.Add(right.Scale(dx)).Add(up.Scale(dy))
+	c.applyNow()
+}
+
+// Zoom scales the distance to the target by 1/factor, so a factor of 2 halves the distance and a factor of 0.5 doubles
+// it. factor must be positive. Distance is clamped to [MinDistance..MaxDistance].
+func (c *OrbitCamera) Zoom(factor float32) {
+	c.pendingZoom -= math32.Log(factor)
+	c.applyNow()
+}
+
+// Dolly moves the camera delta units toward the target, or away from it if delta is negative. Distance is clamped to
+// [MinDistance..MaxDistance].
+func (c *OrbitCamera) Dolly(delta float32) {
+	c.pendingDolly -= delta
+	c.applyNow()
+}
+
+// Arcball rotates the camera around the target as if the cursor had dragged a virtual trackball, centered on the
+// screen, from one position to another. from and to are in normalized device coordinates: [-1..1] on both axes, with
+// positive Y down the screen (see [Viewport.WindowToNDC]). The point on the trackball under from ends up under to.
+//
+// The camera stays upright relative to Up, so any roll component of the drag is discarded.
+func (c *OrbitCamera) Arcball(from, to Pt2) {
+	// Move the trackball points into world space
+	inv := c.View().InverseRigid()
+	a := inv.MultV(arcballPoint(from))
+	b := inv.MultV(arcballPoint(to))
+	q := rotationBetween(a, b)
+
+	// Turning the scene by q is the same as orbiting the camera by the inverse of q
+	yaw, pitch := c.Yaw+c.pendingYaw, c.Pitch+c.pendingPitch
+	d := q.Conjugate().RotateV(c.direction(yaw, pitch))
+
+	u, f, r := orbitBasis(c.Up)
+	newYaw := math32.Atan2(d.Dot(r), d.Dot(f))
+	newPitch := math32.Asin(clamp(d.Dot(u), -1, 1))
+
+	dYaw := math32.Remainder(newYaw-yaw, 2*math32.Pi)
+	c.Rotate(dYaw, newPitch-pitch)
+}
+
+// Update advances damped motion by dt seconds. It does nothing if Smoothing is zero, as motion has already been
+// applied.
+func (c *OrbitCamera) Update(dt float32) {
+	if c.Smoothing <= 0 {
+		return
+	}
+	c.apply(1 - math32.Exp2(-dt/c.Smoothing))
+}
+
+// applyNow applies all pending motion if damping is disabled.
+func (c *OrbitCamera) applyNow() {
+	if c.Smoothing <= 0 {
+		c.apply(1)
+	}
+}
+
+// apply moves fraction of the pending motion into the camera state.
+func (c *OrbitCamera) apply(fraction float32) {
+	c.Yaw += c.pendingYaw * fraction
+	c.Pitch = clamp(c.Pitch+c.pendingPitch*fraction, c.MinPitch, c.MaxPitch)
+	c.Target = c.Target.Add(c.pendingPan.Scale(fraction))
+	c.Distance = clamp(c.Distance*math32.Exp(c.pendingZoom*fraction)+c.pendingDolly*fraction, c.MinDistance, c.MaxDistance)
+
+	keep := 1 - fraction
+	c.pendingYaw *= keep
+	c.pendingPitch *= keep
+	c.pendingPan = c.pendingPan.Scale(keep)
+	c.pendingZoom *= keep
+	c.pendingDolly *= keep
+}
+
+// ArcballRotation returns the rotation of a virtual trackball dragged from one screen position to another, in view
+// space. Positions are in normalized device coordinates, as for [OrbitCamera.Arcball]. Points inside the unit circle
+// lie on the front of the ball; points outside it are projected onto its silhouette. Note that views built with
+// [Camera] flip the Y axis, which reverses the sense of the rotation if its axis is transformed back to world space.
+func ArcballRotation(from, to Pt2) Quat {
+	return rotationBetween(arcballPoint(from), arcballPoint(to))
+}
+
+// arcballPoint maps a position in normalized device coordinates onto the unit trackball in view space, which faces the
+// camera along +Z.
+func arcballPoint(p Pt2) Vec {
+	d := p[0]*p[0] + p[1]*p[1]
+	if d > 1 {
+		s := 1 / math32.Sqrt(d)
+		return NewVec(p[0]*s, p[1]*s, 0)
+	}
+	return NewVec(p[0], p[1], math32.Sqrt(1-d))
+}
+
+// rotationBetween returns the shortest rotation taking unit vector a to unit vector b.
+func rotationBetween(a, b Vec) Quat {
+	axis := a.Cross(b)
+	l := axis.Length()
+	if l < 0.000001 {
+		return IdentityQuat()
+	}
+	return NewQuat(axis.Scale(1/l), math32.Atan2(l, a.Dot(b)))
+}
+
+func clamp(f, min, max float32) float32 {
+	return math32.Max(min, math32.Min(max, f))
+}
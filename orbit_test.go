@@ -0,0 +1,115 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestOrbitCamera(t *testing.T) {
+	target := NewPt(1, 2, 3)
+	c := NewOrbitCamera(target, 5, UnitVecY())
+
+	if exp, res := NewPt(1, 2, 8), c.Eye(); !res.EqualTo(exp) {
+		t.Errorf("Default orbit eye was not on the +Z axis! Expected: %+v Actual: %+v", exp, res)
+	}
+	if exp, res := LookAt(NewPt(1, 2, 8), target, UnitVecY()), c.View(); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Orbit view did not match LookAt! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	c.Rotate(math32.Pi/2, 0)
+	if exp, res := NewPt(6, 2, 3), c.Eye(); !res.EqualTo(exp) {
+		t.Errorf("Positive yaw did not orbit toward +X! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	c.Rotate(0, 10)
+	if c.Pitch != c.MaxPitch {
+		t.Errorf("Pitch was not clamped! Expected: %v Actual: %v", c.MaxPitch, c.Pitch)
+	}
+	if e := c.Eye(); e[1] <= target[1] {
+		t.Errorf("Positive pitch did not raise the eye above the target! Actual: %+v", e)
+	}
+	c.Rotate(0, -c.Pitch)
+
+	c.Zoom(2)
+	if math32.Abs(c.Distance-2.5) > 0.00001 {
+		t.Errorf("Zoom(2) did not halve the distance! Actual: %v", c.Distance)
+	}
+	c.Dolly(-1.5)
+	if math32.Abs(c.Distance-4) > 0.00001 {
+		t.Errorf("Dolly(-1.5) did not move the camera away! Actual: %v", c.Distance)
+	}
+	c.Dolly(100)
+	if c.Distance != c.MinDistance {
+		t.Errorf("Distance was not clamped! Expected: %v Actual: %v", c.MinDistance, c.Distance)
+	}
+	c.Distance = 4
+
+	// Looking down -X from +X, the view's right is -Z and its up is +Y
+	eye := c.Eye()
+	c.Pan(1, 2)
+	if exp := NewPt(1, 4, 2); !c.Target.EqualTo(exp) {
+		t.Errorf("Pan did not move the target along the view axes! Expected: %+v Actual: %+v", exp, c.Target)
+	}
+	if exp, res := eye.Add(NewVec(0, 2, -1)), c.Eye(); !res.EqualTo(exp) {
+		t.Errorf("Pan did not move the eye with the target! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestOrbitCameraSmoothing(t *testing.T) {
+	c := NewOrbitCamera(Origin(), 10, UnitVecY())
+	c.Smoothing = 0.1
+	c.Rotate(1, 0.5)
+	c.Zoom(2)
+
+	if c.Yaw != 0 || c.Pitch != 0 || c.Distance != 10 {
+		t.Errorf("Damped motion was applied before Update! Actual: yaw %v, pitch %v, distance %v", c.Yaw, c.Pitch, c.Distance)
+	}
+
+	c.Update(0.1)
+	if math32.Abs(c.Yaw-0.5) > 0.0001 || math32.Abs(c.Pitch-0.25) > 0.0001 {
+		t.Errorf("One half-life did not apply half the motion! Actual: yaw %v, pitch %v", c.Yaw, c.Pitch)
+	}
+
+	for i := 0; i < 100; i++ {
+		c.Update(0.05)
+	}
+	if math32.Abs(c.Yaw-1) > 0.0001 || math32.Abs(c.Pitch-0.5) > 0.0001 || math32.Abs(c.Distance-5) > 0.0001 {
+		t.Errorf("Damped motion did not converge! Actual: yaw %v, pitch %v, distance %v", c.Yaw, c.Pitch, c.Distance)
+	}
+}
+
+func TestOrbitCameraArcball(t *testing.T) {
+	c := NewOrbitCamera(NewPt(0, 1, 0), 5, UnitVecY())
+	from, to := NewPt2(0, 0), NewPt2(0.5, 0)
+
+	// The world space point under the cursor at from must end up under the cursor at to
+	p := c.View().InverseRigid().MultV(arcballPoint(from))
+	c.Arcball(from, to)
+	exp := arcballPoint(to)
+	if res := c.View().MultV(p); !Pt(res).EqualTo(Pt(exp)) {
+		t.Errorf("Arcball drag did not follow the cursor! Expected: %+v Actual: %+v", exp, res)
+	}
+	if c.Yaw >= 0 || c.Pitch != 0 {
+		t.Errorf("Dragging right did not orbit the camera left! Actual: yaw %v, pitch %v", c.Yaw, c.Pitch)
+	}
+
+	// Dragging down the screen (+Y in Vulkan NDC) tips the top of the scene toward the camera, raising the eye
+	c = NewOrbitCamera(Origin(), 5, UnitVecY())
+	c.Arcball(NewPt2(0, 0), NewPt2(0, 0.3))
+	if c.Pitch <= 0 {
+		t.Errorf("Dragging down did not raise the camera! Actual pitch: %v", c.Pitch)
+	}
+}
+
+func TestArcballRotation(t *testing.T) {
+	from, to := NewPt2(-0.2, 0.3), NewPt2(0.4, -0.1)
+	exp := arcballPoint(to)
+	if res := ArcballRotation(from, to).RotateV(arcballPoint(from)); !Pt(res).EqualTo(Pt(exp)) {
+		t.Errorf("ArcballRotation did not carry the start point to the end point! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	if q := ArcballRotation(from, from); !q.ApproximatelyEquals(IdentityQuat(), 0.000001) {
+		t.Errorf("ArcballRotation without movement was not the identity! Actual: %+v", q)
+	}
+}
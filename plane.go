@@ -0,0 +1,115 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Plane is an infinite plane, defined as the set of points p where Normal.Dot(p) + D = 0. When Normal is a unit vector,
+// -D is the distance from the origin to the plane along Normal, and [Plane.SignedDistance] returns a true distance.
+// The side of the plane that Normal points towards is considered the positive side.
+type Plane struct {
+	Normal Vec
+	D      float32
+}
+
+// NewPlane creates a plane passing through p, facing in the direction of normal. The normal does not need to be a unit
+// vector; the returned plane is normalized.
+func NewPlane(p Pt, normal Vec) Plane {
+	n := normal.Normalize()
+	return Plane{n, -n.Dot(Vec(p))}
+}
+
+// NewPlaneFromPoints creates a plane passing through a, b, and c. The normal faces towards the side from which the
+// points appear in counterclockwise order. The points must not be colinear.
+func NewPlaneFromPoints(a, b, c Pt) Plane {
+	return NewPlane(a, a.VecTo(b).Cross(a.VecTo(c)))
+}
+
+// Normalize returns the same plane scaled so that Normal is a unit vector. A plane with a zero normal is returned
+// unchanged.
+func (pl Plane) Normalize() Plane {
+	l := pl.Normal.Length()
+	if l == 0 {
+		return pl
+	}
+	return Plane{pl.Normal.Scale(1 / l), pl.D / l}
+}
+
+// SignedDistance returns the distance from pl to p, which is positive on the side Normal points towards. The result is
+// only a true distance if pl is normalized.
+func (pl Plane) SignedDistance(p Pt) float32 {
+	return pl.Normal.Dot(Vec(p)) + pl.D
+}
+
+// ProjectPt returns the point on pl closest to p.
+func (pl Plane) ProjectPt(p Pt) Pt {
+	n := pl.Normalize()
+	return p.Add(n.Normal.Scale(-n.SignedDistance(p)))
+}
+
+// IntersectRay returns the distance along r at which it crosses pl, and true if it does. A ray parallel to the plane, or
+// pointing away from it, does not intersect. If pl is not normalized, the distance is still measured in units of r's
+// direction vector.
+func (pl Plane) IntersectRay(r Ray) (float32, bool) {
+	denom := pl.Normal.Dot(r.Direction)
+	if math32.Abs(denom) < 0.000001 {
+		return 0, false
+	}
+	t := -pl.SignedDistance(r.Origin) / denom
+	if t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// TransformPlane returns pl transformed by m. Planes transform by the inverse-transpose of m (like surface normals, see
+// [Mat.NormalMatrix]), so m must be invertible. The result is normalized.
+func (m Mat) TransformPlane(pl Plane) Plane {
+	v := m.Inverse().Transpose().MultV(Vec{pl.Normal[0], pl.Normal[1], pl.Normal[2], pl.D})
+	return planeFromRow(v)
+}
+
+// NewMatReflect generates a matrix that mirrors points across pl, e.g. to render a planar reflection.
+func NewMatReflect(pl Plane) Mat {
+	pl = pl.Normalize()
+	n, d := pl.Normal, pl.D
+	return Mat{
+		{1 - 2*n[0]*n[0], -2 * n[1] * n[0], -2 * n[2] * n[0], 0},
+		{-2 * n[0] * n[1], 1 - 2*n[1]*n[1], -2 * n[2] * n[1], 0},
+		{-2 * n[0] * n[2], -2 * n[1] * n[2], 1 - 2*n[2]*n[2], 0},
+		{-2 * d * n[0], -2 * d * n[1], -2 * d * n[2], 1},
+	}
+}
+
+// Reflect applies a reflection across pl to m and returns the resulting matrix.
+func (m Mat) Reflect(pl Plane) Mat {
+	return NewMatReflect(pl).MultM(m)
+}
+
+// NewMatPlanarShadow generates a matrix that flattens geometry onto pl, as seen from light, to render a projected
+// shadow. light is a homogenous point: use w = 1 for a point light at that position, or w = 0 for a directional light
+// shining along the (negated) xyz direction, i.e. light points towards the light source. The resulting points have
+// w != 1, and must be homogenized (which the GPU does automatically after the vertex shader).
+func NewMatPlanarShadow(pl Plane, light Pt) Mat {
+	p := Vec{pl.Normal[0], pl.Normal[1], pl.Normal[2], pl.D}
+	d := p[0]*light[0] + p[1]*light[1] + p[2]*light[2] + p[3]*light[3]
+
+	var rval Mat
+	for col := range rval {
+		for row := range rval[col] {
+			rval[col][row] = -light[row] * p[col]
+			if row == col {
+				rval[col][row] += d
+			}
+		}
+	}
+	return rval
+}
+
+// planeFromRow builds a plane from a sum of matrix rows, as used by the frustum extraction.
+func planeFromRow(v Vec) Plane {
+	return Plane{NewVec(v[0], v[1], v[2]), v[3]}.Normalize()
+}
+
+// absVec returns the component-wise absolute value of v.
+func absVec(v Vec) Vec {
+	return Vec{math32.Abs(v[0]), math32.Abs(v[1]), math32.Abs(v[2]), math32.Abs(v[3])}
+}
@@ -0,0 +1,96 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestNewPlane(t *testing.T) {
+	pl := NewPlaneFromPoints(NewPt(0, 2, 0), NewPt(1, 2, 0), NewPt(0, 2, -1))
+	exp := Plane{NewVec(0, 1, 0), -2}
+	if pl != exp {
+		t.Errorf("NewPlaneFromPoints failed! Expected: %+v Actual: %+v", exp, pl)
+	}
+	if pl2 := NewPlane(NewPt(5, 2, 5), NewVec(0, 3, 0)); pl2 != exp {
+		t.Errorf("NewPlane failed! Expected: %+v Actual: %+v", exp, pl2)
+	}
+
+	if d := pl.SignedDistance(NewPt(1, 5, 1)); d != 3 {
+		t.Errorf("SignedDistance above the plane failed! Expected: 3 Actual: %v", d)
+	}
+	if d := pl.SignedDistance(Origin()); d != -2 {
+		t.Errorf("SignedDistance below the plane failed! Expected: -2 Actual: %v", d)
+	}
+
+	if p := pl.ProjectPt(NewPt(3, 7, -1)); !p.EqualTo(NewPt(3, 2, -1)) {
+		t.Errorf("ProjectPt failed! Actual: %+v", p)
+	}
+
+	unnormalized := Plane{NewVec(0, 4, 0), -8}
+	if n := unnormalized.Normalize(); n != exp {
+		t.Errorf("Normalize failed! Expected: %+v Actual: %+v", exp, n)
+	}
+}
+
+func TestPlaneIntersectRay(t *testing.T) {
+	pl := NewPlane(NewPt(0, 0, -5), NewVec(0, 0, 1))
+
+	if d, ok := pl.IntersectRay(NewRay(Origin(), NewVec(0, 0, -1))); !ok || d != 5 {
+		t.Errorf("Ray towards the plane failed! Hit: %v Distance: %v", ok, d)
+	}
+	if _, ok := pl.IntersectRay(NewRay(Origin(), NewVec(0, 0, 1))); ok {
+		t.Errorf("Ray away from the plane reported a hit!")
+	}
+	if _, ok := pl.IntersectRay(NewRay(Origin(), NewVec(1, 0, 0))); ok {
+		t.Errorf("Ray parallel to the plane reported a hit!")
+	}
+}
+
+func TestTransformPlane(t *testing.T) {
+	pl := NewPlane(NewPt(0, 1, 0), NewVec(1, 1, 0))
+	m := Identity().Scale(NewVec(3, 1, 2)).RotateZDeg(40).Translate(NewVec(1, 2, 3))
+
+	res := m.TransformPlane(pl)
+
+	// Points on the original plane must land on the transformed plane
+	for _, p := range []Pt{NewPt(0, 1, 0), NewPt(1, 0, 0), NewPt(2, -1, 5)} {
+		if d := res.SignedDistance(m.MultP(p)); math32.Abs(d) > 0.0001 {
+			t.Errorf("Transformed point %+v is not on the transformed plane! Distance: %v", p, d)
+		}
+	}
+	if l := res.Normal.Length(); math32.Abs(l-1) > 0.00001 {
+		t.Errorf("Transformed plane is not normalized! Normal length: %v", l)
+	}
+}
+
+func TestNewMatReflect(t *testing.T) {
+	pl := NewPlane(NewPt(0, 1, 0), NewVec(0, 1, 0))
+	m := NewMatReflect(pl)
+
+	if p := m.MultP(NewPt(2, 3, 4)); !p.EqualTo(NewPt(2, -1, 4)) {
+		t.Errorf("Reflection failed! Actual: %+v", p)
+	}
+	if v := m.MultV(NewVec(1, 1, 0)); !Pt(v).EqualTo(Pt(NewVec(1, -1, 0))) {
+		t.Errorf("Vector reflection failed! Actual: %+v", v)
+	}
+	if r := m.MultM(m); !r.ApproximatelyEquals(Identity(), 0.00001) {
+		t.Errorf("Double reflection was not the identity! Actual: %+v", r)
+	}
+}
+
+func TestNewMatPlanarShadow(t *testing.T) {
+	ground := NewPlane(Origin(), NewVec(0, 1, 0))
+
+	// A point light directly above the origin casts the shadow of (1, 1, 0) to (2, 0, 0)
+	m := NewMatPlanarShadow(ground, NewPt(0, 2, 0))
+	if p := m.MultP(NewPt(1, 1, 0)).Homogenize(); !p.EqualTo(NewPt(2, 0, 0)) {
+		t.Errorf("Point light shadow failed! Actual: %+v", p)
+	}
+
+	// A directional light shining straight down casts the shadow directly below
+	m = NewMatPlanarShadow(ground, Pt{0, 1, 0, 0})
+	if p := m.MultP(NewPt(3, 5, -2)).Homogenize(); !p.EqualTo(NewPt(3, 0, -2)) {
+		t.Errorf("Directional light shadow failed! Actual: %+v", p)
+	}
+}
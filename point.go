@@ -141,3 +141,19 @@ func (p Pt3) VecFrom(q Pt3) Vec3 {
 }
 
 func (p Pt) FlattenToXY() Pt2 { return Pt2{p[0], p[1]} }
+
+// Lerp linearly interpolates between p and q, returning p when t is 0 and q when t is 1. All four components are
+// interpolated.
+func (p Pt) Lerp(q Pt, t float32) Pt {
+	return Pt(Vec(p).Lerp(Vec(q), t))
+}
+
+// Lerp linearly interpolates between p and q, returning p when t is 0 and q when t is 1.
+func (p Pt3) Lerp(q Pt3, t float32) Pt3 {
+	return Pt3(Vec3(p).Lerp(Vec3(q), t))
+}
+
+// Lerp linearly interpolates between p and q, returning p when t is 0 and q when t is 1.
+func (p Pt2) Lerp(q Pt2, t float32) Pt2 {
+	return Pt2(Vec2(p).Lerp(Vec2(q), t))
+}
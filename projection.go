@@ -1,6 +1,14 @@
 package vkm
 
-import "github.com/chewxy/math32"
+import (
+	"errors"
+
+	"github.com/chewxy/math32"
+)
+
+// ErrDegenerateCamera is returned by [CameraChecked] and [LookAtChecked] when the look direction has zero length or
+// the up vector is parallel to it, in which case the orientation of the camera is undefined.
+var ErrDegenerateCamera = errors.New("vkm: camera look direction is zero or parallel to the up vector")
 
 // Perspective generates a perspective projection matrix for Vulkan. Note that in Vulkan, the standard z clipping space is in the range
 // [0..1], and x/y in the range [-1..1] (or rather -w to +w).
@@ -43,16 +51,158 @@ func PerspectiveDeg(fovDeg, aspect, near, far float32) Mat {
 	return Perspective(2.0*math32.Pi*fovDeg/360.0, aspect, near, far)
 }
 
-// Ortho generates an orthogonal projection matrix.
-func OrthoProjection(width, height, near, far float32) Mat {
+// InfinitePerspective generates a Vulkan perspective projection matrix with the far plane at infinity, for scenes
+// where no practical far distance exists. Depth is mapped to 1 - near/distance, so depth approaches, but never
+// reaches, 1.0 as distance increases. Because precision is concentrated near the camera, consider
+// [InvertedDepthInfinitePerspective] for large scenes.
+//
+// Parameters have the same meaning as in [Perspective].
+func InfinitePerspective(fov, aspect, near float32) Mat {
+	f := 1 / math32.Tan(fov/2)
+	return Mat{
+		{f / aspect, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, -1, -1},
+		{0, 0, -near, 0},
+	}
+}
+
+// InfinitePerspectiveDeg generates an infinite perspective projection matrix. This version accepts the FOV angle in
+// degrees. See InfinitePerspective.
+func InfinitePerspectiveDeg(fovDeg, aspect, near float32) Mat {
+	return InfinitePerspective(2.0*math32.Pi*fovDeg/360.0, aspect, near)
+}
+
+// InvertedDepthInfinitePerspective generates a Vulkan perspective matrix with reversed depth and the far plane at
+// infinity. Depth is mapped to near/distance, so the near plane is at 1.0 and depth approaches 0.0 with distance. See
+// [InvertedDepthPerspective] for the required depth buffer configuration. Combined with a floating point depth buffer,
+// this projection provides nearly uniform precision over the entire view distance.
+//
+// Parameters have the same meaning as in [Perspective].
+func InvertedDepthInfinitePerspective(fov, aspect, near float32) Mat {
+	f := 1 / math32.Tan(fov/2)
+	return Mat{ // Clipping Z reversed!
+		{f / aspect, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, 0, -1},
+		{0, 0, near, 0},
+	}
+}
+
+// InvertedDepthInfinitePerspectiveDeg generates a reversed depth, infinite perspective projection matrix. This
+// version accepts the FOV angle in degrees. See InvertedDepthInfinitePerspective.
+func InvertedDepthInfinitePerspectiveDeg(fovDeg, aspect, near float32) Mat {
+	return InvertedDepthInfinitePerspective(2.0*math32.Pi*fovDeg/360.0, aspect, near)
+}
+
+// PerspectiveOffCenter generates a Vulkan perspective projection matrix for an asymmetric (off-center) frustum, as needed
+// for VR eyes, multi-monitor walls and tiled rendering. left, right, bottom and top are the view space coordinates of
+// the edges of the screen on the near plane, and near and far are the distances to the near and far planes, as in
+// [Perspective].
+//
+// Because Vulkan's clip space Y axis points down the screen (and [Camera] produces a view space to match), top is
+// normally less than bottom. Perspective(fov, aspect, near, far) is equivalent to
+//
+//	h := near * math32.Tan(fov/2)
+//	PerspectiveOffCenter(-h*aspect, h*aspect, h, -h, near, far)
+func PerspectiveOffCenter(left, right, bottom, top, near, far float32) Mat {
+	return Mat{
+		{2 * near / (right - left), 0, 0, 0},
+		{0, 2 * near / (bottom - top), 0, 0},
+		{(right + left) / (right - left), (bottom + top) / (bottom - top), far / (near - far), -1},
+		{0, 0, far * near / (near - far), 0},
+	}
+}
+
+// OrthoProjectionOffCenter generates a Vulkan orthographic projection matrix mapping the view space box bounded by
+// left, right, bottom, top, and the near and far distances in front of the camera, to clip space. As with
+// [PerspectiveOffCenter], Y points down the screen, so top is normally less than bottom.
+func OrthoProjectionOffCenter(left, right, bottom, top, near, far float32) Mat {
 	return Mat{
-		{2 / width, 0, 0, 0},
-		{0, 2 / height, 0, 0},
-		{0, 0, 1 / (far - near), (far + near) / (far - near)},
-		{0, 0, 0, 1},
+		{2 / (right - left), 0, 0, 0},
+		{0, 2 / (bottom - top), 0, 0},
+		{0, 0, -1 / (far - near), 0},
+		{-(right + left) / (right - left), -(bottom + top) / (bottom - top), -near / (far - near), 1},
 	}
 }
 
+// ObliqueProjection modifies the perspective projection matrix proj so that its near plane is replaced by clipPlane,
+// using Eric Lengyel's oblique frustum technique. This clips geometry behind a mirror or portal for free, without
+// a user clip plane in the shader. clipPlane is in view space, and its normal must face the visible side, away from
+// the camera (i.e. the camera must be on the negative side of the plane). The far plane is adjusted as well, so
+// depth precision is reduced; keep the clip plane as close to perpendicular to the view direction as possible.
+//
+// proj must use standard [0..1] depth, e.g. from [Perspective] or [InfinitePerspective]. Use
+// [InvertedDepthObliqueProjection] for reversed depth projections.
+func ObliqueProjection(proj Mat, clipPlane Plane) Mat {
+	c, a := obliqueScale(proj, clipPlane, 1)
+	// The depth row becomes the clip plane, scaled so that the far corner of the frustum still maps to depth 1
+	for i := range proj {
+		proj[i][2] = c[i] * a
+	}
+	return proj
+}
+
+// InvertedDepthObliqueProjection is the reversed depth equivalent of [ObliqueProjection], for projection matricies
+// created by [InvertedDepthPerspective] or [InvertedDepthInfinitePerspective].
+func InvertedDepthObliqueProjection(proj Mat, clipPlane Plane) Mat {
+	c, a := obliqueScale(proj, clipPlane, 0)
+	// The near plane is w - z >= 0 with reversed depth, so w - z becomes the clip plane, scaled so that the far corner of
+	// the frustum still maps to depth 0
+	for i := range proj {
+		proj[i][2] = proj[i][3] - c[i]*a
+	}
+	return proj
+}
+
+// obliqueScale returns the clip plane as a Vec, and the scale factor that maps the frustum corner opposite the plane to
+// farDepth.
+func obliqueScale(proj Mat, clipPlane Plane, farDepth float32) (Vec, float32) {
+	c := Vec{clipPlane.Normal[0], clipPlane.Normal[1], clipPlane.Normal[2], clipPlane.D}
+	inv := proj.Inverse()
+
+	// Find the clip space corner of the far plane on the opposite side of the clip plane, and move it back to view
+	// space. Planes transform by the inverse-transpose.
+	cc := inv.Transpose().MultV(c)
+	q := inv.MultV(Vec{sign(cc[0]), sign(cc[1]), farDepth, 1})
+
+	return c, 1 / (c[0]*q[0] + c[1]*q[1] + c[2]*q[2] + c[3]*q[3])
+}
+
+func sign(f float32) float32 {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+// OrthoProjection generates a Vulkan orthographic projection matrix, centered on the view axis. width and height are
+// the size of the view volume, and near and far are the distances to the near and far planes in front of the camera.
+func OrthoProjection(width, height, near, far float32) Mat {
+	return ClipSpaceVulkan.OrthoProjection(width, height, near, far)
+}
+
+// Project transforms the world-space point obj by the view and projection matricies and maps the result to window
+// coordinates within viewport. The viewport is given as {x, y, width, height} in pixels, with the origin at the top
+// left, matching VkViewport. The X and Y components of the result are in pixels and the Z component is the depth in
+// the range [0..1]. Use [Viewport.Project] for other depth ranges.
+func Project(obj Pt, view, proj Mat, viewport Vec) Pt3 {
+	return NewViewportFromVec(viewport).Project(obj, view, proj)
+}
+
+// Unproject is the inverse of [Project], mapping a window coordinate and depth back into world space. Because Vulkan's
+// clip space Y axis points down the screen, no flip is needed between window and clip coordinates. If the depth
+// corresponds to a far plane at infinity, the result will have infinite components.
+//
+// Unproject inverts the combined view-projection matrix on each call. If you are unprojecting many points with the same
+// matricies, invert proj.MultM(view) yourself and reuse it.
+func Unproject(win Pt3, view, proj Mat, viewport Vec) Pt {
+	return NewViewportFromVec(viewport).Unproject(win, view, proj)
+}
+
 // LookAt creates a view matrix from the provided eye and focus points, and an
 // up vector. This function does NOT generate a persepctive matrix. Note that LookAt (and all projection
 // helpers in this library) are based on Vulkan's clip space, which differs from OpenGL.
@@ -65,8 +215,16 @@ func LookAt(eye, focus Pt, up Vec) Mat {
 	return Camera(eye, focus.VecFrom(eye), up)
 }
 
+// LookAtChecked is identical to [LookAt], but returns ErrDegenerateCamera instead of an invalid matrix if focus is the
+// same point as eye, or if up is parallel to the direction from eye to focus.
+func LookAtChecked(eye, focus Pt, up Vec) (Mat, error) {
+	return CameraChecked(eye, focus.VecFrom(eye), up)
+}
+
 // Camera creates a view matrix from the provided eye location, pointed along the direction vector. Camera will continue to look "forward" as you move the eye point, while [LookAt] will change the look direction
 // to continue pointing at the focus point as the eye moves around.
+//
+// If look is parallel to up, the result is a matrix of NaN values. Use [CameraChecked] if that is possible.
 func Camera(eye Pt, look Vec, up Vec) Mat {
 	// x := Mat{{1, 0, 0, 0}, {0, -1, 0, 0}, {0, 0, -1, 0}, {0, 0, 0, 1}}.Inverse()
 
@@ -81,5 +239,19 @@ func Camera(eye Pt, look Vec, up Vec) Mat {
 	m := Mat{u, v, w, {0, 0, 0, 1}} //.Transpose() //.Translate()
 	r := t.MultM(m)
 
-	return r.Inverse()
+	return r.InverseRigid()
+}
+
+// CameraChecked is identical to [Camera], but returns ErrDegenerateCamera instead of a matrix of NaN values if look
+// has zero length, or if up is zero or parallel to look.
+func CameraChecked(eye Pt, look Vec, up Vec) (Mat, error) {
+	ll, ul := look.Length(), up.Length()
+	if ll == 0 || ul == 0 {
+		return Mat{}, ErrDegenerateCamera
+	}
+	// |a x b| = |a||b|sin(theta), so this compares the sine of the angle between look and up against the tolerance
+	if look.Cross(up).Length()/(ll*ul) < 0.00001 {
+		return Mat{}, ErrDegenerateCamera
+	}
+	return Camera(eye, look, up), nil
 }
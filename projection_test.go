@@ -2,6 +2,8 @@ package vkm
 
 import (
 	"testing"
+
+	"github.com/chewxy/math32"
 )
 
 func TestPerspective(t *testing.T) {
@@ -68,3 +70,169 @@ func TestLookAt(t *testing.T) {
 	}
 
 }
+
+func TestCameraChecked(t *testing.T) {
+	if _, err := CameraChecked(Origin(), NewVec(0, 2, 0), NewVec(0, 1, 0)); err != ErrDegenerateCamera {
+		t.Errorf("Camera with parallel look and up vectors did not return ErrDegenerateCamera! Actual: %v", err)
+	}
+	if _, err := CameraChecked(Origin(), NewVec(0, -1, 0), NewVec(0, 1, 0)); err != ErrDegenerateCamera {
+		t.Errorf("Camera with anti-parallel look and up vectors did not return ErrDegenerateCamera! Actual: %v", err)
+	}
+	if _, err := LookAtChecked(NewPt(1, 1, 1), NewPt(1, 1, 1), NewVec(0, 1, 0)); err != ErrDegenerateCamera {
+		t.Errorf("LookAt with eye equal to focus did not return ErrDegenerateCamera! Actual: %v", err)
+	}
+
+	eye, focus, up := NewPt(5, 0, 0), Origin(), NewVec(0, 1, 0)
+	m, err := LookAtChecked(eye, focus, up)
+	if err != nil {
+		t.Errorf("LookAtChecked returned an error for a valid camera: %v", err)
+	}
+	if exp := LookAt(eye, focus, up); !m.Equals(exp) {
+		t.Errorf("LookAtChecked did not match LookAt! Expected: %+v Actual: %+v", exp, m)
+	}
+}
+
+func TestProjectUnproject(t *testing.T) {
+	view := LookAt(NewPt(3, 4, 5), NewPt(0, 1, 0), NewVec(0, 1, 0))
+	viewport := Vec{10, 20, 800, 600}
+	p := NewPt(0.5, 1.5, -1)
+
+	for _, proj := range []Mat{
+		PerspectiveDeg(60, 800.0/600.0, 0.1, 100),
+		InvertedDepthPerspective(1, 800.0/600.0, 0.1, 100),
+		OrthoProjection(20, 15, 0.1, 100),
+	} {
+		win := Project(p, view, proj, viewport)
+		if win[0] < viewport[0] || win[0] > viewport[0]+viewport[2] || win[1] < viewport[1] || win[1] > viewport[1]+viewport[3] {
+			t.Errorf("Projected point is outside the viewport! Actual: %+v", win)
+		}
+
+		res := Unproject(win, view, proj, viewport)
+		if !testApproxPt(res, p, 0.0001) {
+			t.Errorf("Unproject did not invert Project! Expected: %+v Actual: %+v", p, res)
+		}
+	}
+
+	// Positive Y in clip space is down the screen
+	proj := PerspectiveDeg(90, 1, 1, 10)
+	top := Project(NewPt(0, 1, -5), Identity(), proj, Vec{0, 0, 100, 100})
+	if top[1] < 50 {
+		t.Errorf("Point projected to the wrong half of the screen! Actual: %+v", top)
+	}
+}
+
+func testApproxPt(p, q Pt, precision float32) bool {
+	return Mat{Vec(p)}.ApproximatelyEquals(Mat{Vec(q)}, precision)
+}
+
+func TestInfinitePerspective(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		mat                 Mat
+		nearDepth, farDepth float32
+	}{
+		{"standard", InfinitePerspectiveDeg(90, 1, 2), 0, 1},
+		{"inverted", InvertedDepthInfinitePerspectiveDeg(90, 1, 2), 1, 0},
+	} {
+		near := tc.mat.MultP(NewPt(0, 0, -2))
+		if testClipped(near) {
+			t.Errorf("%s: point on near plane was clipped! Result: %+v", tc.name, near)
+		}
+		if d := near.Homogenize()[2]; d != tc.nearDepth {
+			t.Errorf("%s: near plane was not mapped to depth %v! Actual: %v", tc.name, tc.nearDepth, d)
+		}
+
+		if r := tc.mat.MultP(NewPt(0, 0, -1)); !testClipped(r) {
+			t.Errorf("%s: point in front of near plane was not clipped! Result: %+v", tc.name, r)
+		}
+		if r := tc.mat.MultP(NewPt(0, 0, 1)); !testClipped(r) {
+			t.Errorf("%s: point behind view was not clipped! Result: %+v", tc.name, r)
+		}
+
+		prev := tc.nearDepth
+		for _, dist := range []float32{10, 1000, 1e6, 1e20} {
+			r := tc.mat.MultP(NewPt(dist, dist, -dist))
+			if testClipped(r) {
+				t.Errorf("%s: distant point at %v was clipped! Result: %+v", tc.name, dist, r)
+			}
+			d := r.Homogenize()[2]
+			if (tc.farDepth > tc.nearDepth && d < prev) || (tc.farDepth < tc.nearDepth && d > prev) {
+				t.Errorf("%s: depth did not increase monotonically with distance at %v! Previous: %v Actual: %v", tc.name, dist, prev, d)
+			}
+			prev = d
+		}
+	}
+}
+
+func TestPerspectiveOffCenter(t *testing.T) {
+	h := float32(2) // 90 degree FOV with a near plane at 2
+	sym := PerspectiveOffCenter(-h*1.5, h*1.5, h, -h, 2, 10)
+	if exp := PerspectiveDeg(90, 1.5, 2, 10); !sym.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Symmetric off-center perspective did not match Perspective! Expected: %+v Actual: %+v", exp, sym)
+	}
+
+	m := PerspectiveOffCenter(-1, 3, 2, -0.5, 1, 10)
+	cases := []struct {
+		p, exp Pt
+	}{
+		{NewPt(-1, -0.5, -1), NewPt(-1, -1, 0)}, // top left corner of the near plane
+		{NewPt(3, 2, -1), NewPt(1, 1, 0)},       // bottom right corner of the near plane
+		{NewPt(30, 20, -10), NewPt(1, 1, 1)},    // bottom right corner of the far plane
+	}
+	for _, c := range cases {
+		if res := m.MultP(c.p).Homogenize(); !res.EqualTo(c.exp) {
+			t.Errorf("PerspectiveOffCenter mapped %+v incorrectly! Expected: %+v Actual: %+v", c.p, c.exp, res)
+		}
+	}
+}
+
+func TestOrthoProjectionOffCenter(t *testing.T) {
+	m := OrthoProjectionOffCenter(-1, 3, 2, -0.5, 1, 10)
+	cases := []struct {
+		p, exp Pt
+	}{
+		{NewPt(-1, -0.5, -1), NewPt(-1, -1, 0)},
+		{NewPt(3, 2, -10), NewPt(1, 1, 1)},
+		{NewPt(1, 0.75, -5.5), NewPt(0, 0, 0.5)},
+	}
+	for _, c := range cases {
+		res := m.MultP(c.p)
+		if res[3] != 1 {
+			t.Errorf("OrthoProjectionOffCenter produced a non-affine result! Actual: %+v", res)
+		}
+		if !res.EqualTo(c.exp) {
+			t.Errorf("OrthoProjectionOffCenter mapped %+v incorrectly! Expected: %+v Actual: %+v", c.p, c.exp, res)
+		}
+	}
+}
+
+func TestObliqueProjection(t *testing.T) {
+	// A mirror 5 units in front of the camera, tilted 30 degrees around the Y axis. Its normal faces away from the
+	// camera, towards the reflected scene.
+	normal := NewMatRotateYDeg(30).MultV(NewVec(0, 0, -1))
+	clip := NewPlane(NewPt(0, 0, -5), normal)
+
+	for _, tc := range []struct {
+		name      string
+		proj      Mat
+		nearDepth float32
+	}{
+		{"standard", ObliqueProjection(PerspectiveDeg(90, 1, 1, 100), clip), 0},
+		{"inverted", InvertedDepthObliqueProjection(InvertedDepthPerspective(math32.Pi/2, 1, 1, 100), clip), 1},
+	} {
+		// Points on the clip plane land on the new near plane
+		for _, p := range []Pt{NewPt(0, 0, -5), NewPt(0, 2, -5), clip.ProjectPt(NewPt(1, 1, -6))} {
+			r := tc.proj.MultP(p).Homogenize()
+			if math32.Abs(r[2]-tc.nearDepth) > 0.0001 {
+				t.Errorf("%s: point %+v on the clip plane did not map to the near plane! Actual: %+v", tc.name, p, r)
+			}
+		}
+
+		if r := tc.proj.MultP(NewPt(0, 0, -4)); !testClipped(r) {
+			t.Errorf("%s: point in front of the clip plane was not clipped! Result: %+v", tc.name, r)
+		}
+		if r := tc.proj.MultP(NewPt(0, 0, -20)); testClipped(r) {
+			t.Errorf("%s: point beyond the clip plane was clipped! Result: %+v", tc.name, r)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Quat is a rotation quaternion, stored as the imaginary i, j, and k components followed by the real (w) component. Like
+// the other types in this package, it is fundamentally an array, so components can be addressed directly: q[3] is the
+// real part.
+//
+// Quaternions used for rotation must be unit length. The New... functions always return unit quaternions, but repeated
+// multiplication will slowly accumulate error, so call Normalize periodically if you are accumulating rotations.
+type Quat [4]float32
+
+// IdentityQuat returns the quaternion representing no rotation.
+func IdentityQuat() Quat {
+	return Quat{0, 0, 0, 1}
+}
+
+// NewQuat generates a quaternion representing a CCW rotation by theta radians around an arbitrary axis. The rotation
+// is the same as the one generated by [NewMatRotate]. Note that axis is assumed to be a unit vector.
+func NewQuat(axis Vec, theta float32) Quat {
+	s := math32.Sin(theta / 2)
+	return Quat{axis[0] * s, axis[1] * s, axis[2] * s, math32.Cos(theta / 2)}
+}
+
+// NewQuatDeg generates a quaternion representing a CCW rotation by deg degrees around an arbitrary axis. Note that axis
+// is assumed to be a unit vector.
+func NewQuatDeg(axis Vec, deg float32) Quat {
+	return NewQuat(axis, 2*math32.Pi*deg/360.0)
+}
+
+// NewQuatFromMat extracts the rotation from the upper 3x3 of m as a quaternion. m is assumed to be a pure rotation
+// matrix; any scale or shear in m will produce an invalid result.
+func NewQuatFromMat(m Mat) Quat {
+	// Shepperd's method, choosing the largest diagonal term to avoid dividing by a number close to zero.
+	trace := m[0][0] + m[1][1] + m[2][2]
+
+	switch {
+	case trace > 0:
+		s := math32.Sqrt(trace+1) * 2
+		return Quat{
+			(m[1][2] - m[2][1]) / s,
+			(m[2][0] - m[0][2]) / s,
+			(m[0][1] - m[1][0]) / s,
+			s / 4,
+		}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := math32.Sqrt(1+m[0][0]-m[1][1]-m[2][2]) * 2
+		return Quat{
+			s / 4,
+			(m[1][0] + m[0][1]) / s,
+			(m[2][0] + m[0][2]) / s,
+			(m[1][2] - m[2][1]) / s,
+		}
+	case m[1][1] > m[2][2]:
+		s := math32.Sqrt(1+m[1][1]-m[0][0]-m[2][2]) * 2
+		return Quat{
+			(m[1][0] + m[0][1]) / s,
+			s / 4,
+			(m[2][1] + m[1][2]) / s,
+			(m[2][0] - m[0][2]) / s,
+		}
+	default:
+		s := math32.Sqrt(1+m[2][2]-m[0][0]-m[1][1]) * 2
+		return Quat{
+			(m[2][0] + m[0][2]) / s,
+			(m[2][1] + m[1][2]) / s,
+			s / 4,
+			(m[0][1] - m[1][0]) / s,
+		}
+	}
+}
+
+// AsMat converts q into a rotation matrix. q is assumed to be a unit quaternion.
+func (q Quat) AsMat() Mat {
+	xx, yy, zz := q[0]*q[0], q[1]*q[1], q[2]*q[2]
+	xy, xz, yz := q[0]*q[1], q[0]*q[2], q[1]*q[2]
+	wx, wy, wz := q[3]*q[0], q[3]*q[1], q[3]*q[2]
+
+	return Mat{
+		{1 - 2*(yy+zz), 2 * (xy + wz), 2 * (xz - wy), 0},
+		{2 * (xy - wz), 1 - 2*(xx+zz), 2 * (yz + wx), 0},
+		{2 * (xz + wy), 2 * (yz - wx), 1 - 2*(xx+yy), 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// NewMatRotateQuat generates a rotation matrix from q. This is equivalent to q.AsMat().
+func NewMatRotateQuat(q Quat) Mat {
+	return q.AsMat()
+}
+
+// RotateQuat applies the rotation represented by q to m and returns the resulting matrix.
+func (m Mat) RotateQuat(q Quat) Mat {
+	return q.AsMat().MultM(m)
+}
+
+// Mult returns the Hamilton product q x r. As with matrices, the result applies the rotation r first, followed by q:
+// q.Mult(r).AsMat() is equivalent to q.AsMat().MultM(r.AsMat()).
+func (q Quat) Mult(r Quat) Quat {
+	return Quat{
+		q[3]*r[0] + q[0]*r[3] + q[1]*r[2] - q[2]*r[1],
+		q[3]*r[1] - q[0]*r[2] + q[1]*r[3] + q[2]*r[0],
+		q[3]*r[2] + q[0]*r[1] - q[1]*r[0] + q[2]*r[3],
+		q[3]*r[3] - q[0]*r[0] - q[1]*r[1] - q[2]*r[2],
+	}
+}
+
+// Conjugate returns the conjugate of q, with the imaginary components negated. For a unit quaternion, this is the
+// inverse rotation.
+func (q Quat) Conjugate() Quat {
+	return Quat{-q[0], -q[1], -q[2], q[3]}
+}
+
+// Inverse returns the inverse of q, such that q.Mult(q.Inverse()) is the identity quaternion. If q is known to be a unit
+// quaternion, [Quat.Conjugate] is equivalent and cheaper.
+func (q Quat) Inverse() Quat {
+	l := q.SquareLength()
+	return Quat{-q[0] / l, -q[1] / l, -q[2] / l, q[3] / l}
+}
+
+// Dot returns the four dimensional dot product of q and r.
+func (q Quat) Dot(r Quat) float32 {
+	return q[0]*r[0] + q[1]*r[1] + q[2]*r[2] + q[3]*r[3]
+}
+
+// Length is the magnitude of q. A rotation quaternion should always have a length of 1.
+func (q Quat) Length() float32 {
+	return math32.Sqrt(q.SquareLength())
+}
+
+// SquareLength is the dot product of q with itself, equal to the length of q squared.
+func (q Quat) SquareLength() float32 {
+	return q.Dot(q)
+}
+
+// Normalize returns a unit quaternion representing the same rotation as q.
+func (q Quat) Normalize() Quat {
+	l := q.Length()
+	return Quat{q[0] / l, q[1] / l, q[2] / l, q[3] / l}
+}
+
+// AxisAngle returns the unit rotation axis and the rotation angle in radians represented by q. If q is (approximately)
+// the identity rotation, the axis is arbitrary and UnitVecX is returned.
+func (q Quat) AxisAngle() (Vec, float32) {
+	w := math32.Max(-1, math32.Min(1, q[3]))
+	theta := 2 * math32.Acos(w)
+	s := math32.Sqrt(1 - w*w)
+	if s < 0.000001 {
+		return UnitVecX(), theta
+	}
+	return NewVec(q[0]/s, q[1]/s, q[2]/s), theta
+}
+
+// RotateV rotates v by q. The w component of v is left unchanged.
+func (q Quat) RotateV(v Vec) Vec {
+	// v' = v + 2w(u x v) + 2u x (u x v), where u is the vector part of q
+	u := NewVec(q[0], q[1], q[2])
+	t := u.Cross(v).Scale(2)
+	r := v.Add(t.Scale(q[3])).Add(u.Cross(t))
+	r[3] = v[3]
+	return r
+}
+
+// RotateP rotates p around the origin by q.
+func (q Quat) RotateP(p Pt) Pt {
+	return Pt(q.RotateV(Vec(p)))
+}
+
+// ApproximatelyEquals returns true if q and r are equal component-for-component to within `precision`. Note that q and
+// -q represent the same rotation, but are not considered equal by this function.
+func (q Quat) ApproximatelyEquals(r Quat, precision float32) bool {
+	for i := range q {
+		if math32.Abs(q[i]-r[i]) > precision {
+			return false
+		}
+	}
+	return true
+}
+
+// Slerp performs spherical linear interpolation from q to r, returning q when t is 0 and r when t is 1. The result
+// rotates at a constant angular velocity as t varies, and always follows the shortest path between the two
+// orientations. Both q and r are assumed to be unit quaternions.
+//
+// The endpoints are returned exactly, as given. Between them, if q and r lie in opposite hemispheres (q.Dot(r) < 0),
+// the path runs towards -r, which represents the same rotation as r.
+func (q Quat) Slerp(r Quat, t float32) Quat {
+	switch t {
+	case 0:
+		return q
+	case 1:
+		return r
+	}
+	if q.Dot(r) < 0 {
+		r = r.negate()
+	}
+	return q.slerp(r, t)
+}
+
+// slerp interpolates without any shortest path correction, as required by squad.
+func (q Quat) slerp(r Quat, t float32) Quat {
+	d := q.Dot(r)
+	if d > 0.9995 {
+		// The quaternions are nearly parallel and sin(theta) approaches zero, so fall back to a normalized lerp.
+		return q.lerp(r, t).Normalize()
+	}
+	d = math32.Max(-1, math32.Min(1, d))
+
+	theta := math32.Acos(d)
+	st := math32.Sin(theta)
+	a := math32.Sin((1-t)*theta) / st
+	b := math32.Sin(t*theta) / st
+
+	return Quat{
+		a*q[0] + b*r[0],
+		a*q[1] + b*r[1],
+		a*q[2] + b*r[2],
+		a*q[3] + b*r[3],
+	}
+}
+
+// Nlerp performs a normalized linear interpolation from q to r along the shortest path. Nlerp is cheaper than
+// [Quat.Slerp] and follows the same path, but the angular velocity is not constant; it speeds up in the middle of the
+// interpolation. The difference is negligible for small angles, such as between animation frames. As with Slerp, the
+// endpoints are returned exactly when t is 0 or 1.
+func (q Quat) Nlerp(r Quat, t float32) Quat {
+	switch t {
+	case 0:
+		return q
+	case 1:
+		return r
+	}
+	if q.Dot(r) < 0 {
+		r = r.negate()
+	}
+	return q.lerp(r, t).Normalize()
+}
+
+func (q Quat) lerp(r Quat, t float32) Quat {
+	return Quat{
+		q[0] + (r[0]-q[0])*t,
+		q[1] + (r[1]-q[1])*t,
+		q[2] + (r[2]-q[2])*t,
+		q[3] + (r[3]-q[3])*t,
+	}
+}
+
+func (q Quat) negate() Quat {
+	return Quat{-q[0], -q[1], -q[2], -q[3]}
+}
+
+// Log returns the natural logarithm of the unit quaternion q, a pure quaternion (w = 0) whose vector part is the
+// rotation axis scaled by half the rotation angle.
+func (q Quat) Log() Quat {
+	w := math32.Max(-1, math32.Min(1, q[3]))
+	halfTheta := math32.Acos(w)
+	s := math32.Sin(halfTheta)
+	if s < 0.000001 {
+		return Quat{q[0], q[1], q[2], 0}
+	}
+	k := halfTheta / s
+	return Quat{q[0] * k, q[1] * k, q[2] * k, 0}
+}
+
+// Exp returns the exponential of the pure quaternion q. This is the inverse of [Quat.Log]; the w component of q is
+// ignored.
+func (q Quat) Exp() Quat {
+	halfTheta := math32.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2])
+	if halfTheta < 0.000001 {
+		return Quat{q[0], q[1], q[2], 1}.Normalize()
+	}
+	k := math32.Sin(halfTheta) / halfTheta
+	return Quat{q[0] * k, q[1] * k, q[2] * k, math32.Cos(halfTheta)}
+}
+
+// SquadControlPoint computes the inner control point for keyframe cur, given the previous and next keyframes, for use
+// with [Quat.Squad]. For the first or last keyframe in a sequence, pass that keyframe as both cur and the missing
+// neighbor.
+func SquadControlPoint(prev, cur, next Quat) Quat {
+	// Keep the neighbors in the same hemisphere as cur so that the logarithms measure the short way around.
+	if cur.Dot(prev) < 0 {
+		prev = prev.negate()
+	}
+	if cur.Dot(next) < 0 {
+		next = next.negate()
+	}
+
+	inv := cur.Conjugate()
+	ln := inv.Mult(next).Log()
+	lp := inv.Mult(prev).Log()
+
+	return cur.Mult(Quat{
+		-(ln[0] + lp[0]) / 4,
+		-(ln[1] + lp[1]) / 4,
+		-(ln[2] + lp[2]) / 4,
+		0,
+	}.Exp())
+}
+
+// Squad performs spherical cubic interpolation from q to r, using the control points a and b generated by
+// [SquadControlPoint] for q and r respectively. Unlike a chain of [Quat.Slerp] calls, a sequence of squad segments
+// has a continuous angular velocity across keyframes. The keyframes should be pre-processed so that each has a
+// non-negative dot product with the one before it.
+func (q Quat) Squad(r, a, b Quat, t float32) Quat {
+	return q.slerp(r, t).slerp(a.slerp(b, t), 2*t*(1-t))
+}
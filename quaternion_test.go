@@ -0,0 +1,217 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestQuatAsMat(t *testing.T) {
+	axis := NewVec(1, 2, 3).Normalize()
+	q := NewQuat(axis, 1.2)
+	exp := NewMatRotate(axis, 1.2)
+	res := q.AsMat()
+
+	if !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Quat.AsMat did not match NewMatRotate! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	res1 := Identity().RotateQuat(NewQuatDeg(UnitVecX(), 90))
+	exp1 := Identity().RotateXDeg(90)
+	if !res1.ApproximatelyEquals(exp1, 0.00001) {
+		t.Errorf("RotateQuat did not match RotateXDeg! Expected: %+v Actual: %+v", exp1, res1)
+	}
+}
+
+func TestNewQuatFromMat(t *testing.T) {
+	axes := []Vec{
+		UnitVecX(),
+		UnitVecY(),
+		UnitVecZ(),
+		NewVec(1, -1, 2).Normalize(),
+	}
+	// Angles chosen to exercise each branch of the conversion, including near-pi rotations with a negative trace.
+	angles := []float32{0, 0.5, 2, 3, math32.Pi}
+
+	for _, axis := range axes {
+		for _, theta := range angles {
+			q := NewQuat(axis, theta)
+			res := NewQuatFromMat(q.AsMat())
+			// q and -q are the same rotation
+			if res.Dot(q) < 0 {
+				res = Quat{-res[0], -res[1], -res[2], -res[3]}
+			}
+			if !res.ApproximatelyEquals(q, 0.0001) {
+				t.Errorf("Quat to Mat round trip failed for axis %+v, theta %v! Expected: %+v Actual: %+v", axis, theta, q, res)
+			}
+		}
+	}
+}
+
+func TestQuatMult(t *testing.T) {
+	q := NewQuatDeg(UnitVecX(), 30)
+	r := NewQuatDeg(NewVec(0, 1, 1).Normalize(), 75)
+
+	exp := q.AsMat().MultM(r.AsMat())
+	res := q.Mult(r).AsMat()
+	if !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Quat.Mult did not match matrix multiplication! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	id := q.Mult(q.Inverse())
+	if !id.ApproximatelyEquals(IdentityQuat(), 0.00001) {
+		t.Errorf("q x q.Inverse() was not the identity! Actual: %+v", id)
+	}
+
+	id = q.Mult(q.Conjugate())
+	if !id.ApproximatelyEquals(IdentityQuat(), 0.00001) {
+		t.Errorf("q x q.Conjugate() was not the identity! Actual: %+v", id)
+	}
+}
+
+func TestQuatRotate(t *testing.T) {
+	q := NewQuatDeg(UnitVecZ(), 90)
+	pt := NewPt(1, 1, 1)
+
+	exp := NewPt(-1, 1, 1)
+	res := q.RotateP(pt)
+	if !res.EqualTo(exp) {
+		t.Errorf("RotateP by 90 degrees around Z failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	axis := NewVec(1, 1, 0).Normalize()
+	v := NewVec(3, -2, 5)
+	expV := NewMatRotate(axis, 2.5).MultV(v)
+	resV := NewQuat(axis, 2.5).RotateV(v)
+	if !Pt(resV).EqualTo(Pt(expV)) {
+		t.Errorf("RotateV did not match NewMatRotate! Expected: %+v Actual: %+v", expV, resV)
+	}
+}
+
+func TestQuatAxisAngle(t *testing.T) {
+	axis := NewVec(0, 3, 4).Normalize()
+	resAxis, resTheta := NewQuat(axis, 1.5).AxisAngle()
+
+	if math32.Abs(resTheta-1.5) > 0.00001 || !Pt(resAxis).EqualTo(Pt(axis)) {
+		t.Errorf("AxisAngle failed! Expected: %+v, %v Actual: %+v, %v", axis, 1.5, resAxis, resTheta)
+	}
+}
+
+func TestQuatSlerp(t *testing.T) {
+	q := NewQuatDeg(UnitVecY(), 10)
+	r := NewQuatDeg(NewVec(1, 1, 0).Normalize(), 150)
+
+	if res := q.Slerp(r, 0); !res.ApproximatelyEquals(q, 0.000001) {
+		t.Errorf("Slerp at t=0 did not return the start point! Expected: %+v Actual: %+v", q, res)
+	}
+	if res := q.Slerp(r, 1); !res.ApproximatelyEquals(r, 0.000001) {
+		t.Errorf("Slerp at t=1 did not return the end point! Expected: %+v Actual: %+v", r, res)
+	}
+
+	// Equal steps in t must sweep equal angles
+	const steps = 10
+	total := angleBetween(q, r)
+	prev := q
+	for i := 1; i <= steps; i++ {
+		cur := q.Slerp(r, float32(i)/steps)
+		if step := angleBetween(prev, cur); math32.Abs(step-total/steps) > 0.0001 {
+			t.Errorf("Slerp angular velocity was not constant at step %d! Expected: %v Actual: %v", i, total/steps, step)
+		}
+		prev = cur
+	}
+
+	// r and -r are the same orientation, so the result must be identical
+	rNeg := Quat{-r[0], -r[1], -r[2], -r[3]}
+	if a, b := q.Slerp(r, 0.3), q.Slerp(rNeg, 0.3); !a.ApproximatelyEquals(b, 0.00001) {
+		t.Errorf("Slerp did not take the shortest path! Expected: %+v Actual: %+v", a, b)
+	}
+}
+
+func TestQuatInterpolationEndpoints(t *testing.T) {
+	// 10 and 300 degrees about Y lie in opposite hemispheres, and 10 and 10.01 degrees hit the nearly parallel lerp
+	q := NewQuatDeg(UnitVecY(), 10)
+	for _, r := range []Quat{NewQuatDeg(UnitVecY(), 300), NewQuatDeg(UnitVecY(), 10.01)} {
+		if res := q.Slerp(r, 0); res != q {
+			t.Errorf("Slerp at t=0 was not exactly the start point! Expected: %+v Actual: %+v", q, res)
+		}
+		if res := q.Slerp(r, 1); res != r {
+			t.Errorf("Slerp at t=1 was not exactly the end point! Expected: %+v Actual: %+v", r, res)
+		}
+		if res := q.Nlerp(r, 0); res != q {
+			t.Errorf("Nlerp at t=0 was not exactly the start point! Expected: %+v Actual: %+v", q, res)
+		}
+		if res := q.Nlerp(r, 1); res != r {
+			t.Errorf("Nlerp at t=1 was not exactly the end point! Expected: %+v Actual: %+v", r, res)
+		}
+	}
+
+	// Just short of the end, the opposite hemisphere path is still the shortest, ending at -r
+	r := NewQuatDeg(UnitVecY(), 300)
+	if res := q.Slerp(r, 0.999); res.Dot(r) > -0.999 {
+		t.Errorf("Slerp did not approach -r from the opposite hemisphere! Actual: %+v", res)
+	}
+}
+
+func angleBetween(q, r Quat) float32 {
+	return 2 * math32.Acos(math32.Min(1, math32.Abs(q.Dot(r))))
+}
+
+func TestQuatNlerp(t *testing.T) {
+	q := NewQuatDeg(UnitVecZ(), 20)
+	r := NewQuatDeg(UnitVecZ(), 80)
+
+	exp := NewQuatDeg(UnitVecZ(), 50)
+	if res := q.Nlerp(r, 0.5); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Nlerp midpoint failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	rNeg := Quat{-r[0], -r[1], -r[2], -r[3]}
+	if res := q.Nlerp(rNeg, 0.5); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Nlerp did not take the shortest path! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestQuatSquad(t *testing.T) {
+	keys := []Quat{
+		IdentityQuat(),
+		NewQuatDeg(UnitVecX(), 60),
+		NewQuatDeg(NewVec(1, 1, 0).Normalize(), 120),
+		NewQuatDeg(UnitVecZ(), 90),
+	}
+	ctrl := make([]Quat, len(keys))
+	for i := range keys {
+		prev, next := keys[i], keys[i]
+		if i > 0 {
+			prev = keys[i-1]
+		}
+		if i < len(keys)-1 {
+			next = keys[i+1]
+		}
+		ctrl[i] = SquadControlPoint(prev, keys[i], next)
+	}
+
+	for i := 0; i < len(keys)-1; i++ {
+		q, r := keys[i], keys[i+1]
+		if res := q.Squad(r, ctrl[i], ctrl[i+1], 0); !res.ApproximatelyEquals(q, 0.00001) {
+			t.Errorf("Squad at t=0 did not return the start point! Expected: %+v Actual: %+v", q, res)
+		}
+		if res := q.Squad(r, ctrl[i], ctrl[i+1], 1); !res.ApproximatelyEquals(r, 0.00001) {
+			t.Errorf("Squad at t=1 did not return the end point! Expected: %+v Actual: %+v", r, res)
+		}
+	}
+
+	// With equal controls and keys on a single great arc, squad reduces to slerp
+	q := NewQuatDeg(UnitVecY(), 0)
+	r := NewQuatDeg(UnitVecY(), 90)
+	exp := q.Slerp(r, 0.25)
+	if res := q.Squad(r, q, r, 0.25); !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Degenerate squad did not match slerp! Expected: %+v Actual: %+v", exp, res)
+	}
+}
+
+func TestQuatLogExp(t *testing.T) {
+	q := NewQuatDeg(NewVec(1, 2, 2).Normalize(), 100)
+	if res := q.Log().Exp(); !res.ApproximatelyEquals(q, 0.00001) {
+		t.Errorf("Log/Exp round trip failed! Expected: %+v Actual: %+v", q, res)
+	}
+}
@@ -0,0 +1,25 @@
+package vkm
+
+// Ray is a half-line starting at Origin and extending infinitely along Direction.
+type Ray struct {
+	Origin    Pt
+	Direction Vec
+}
+
+// NewRay creates a ray from the provided origin and direction. The direction is normalized, so that the parameter
+// passed to [Ray.At] is a distance along the ray.
+func NewRay(origin Pt, direction Vec) Ray {
+	return Ray{origin, direction.Normalize()}
+}
+
+// At returns the point at distance t along r, assuming the direction of r is a unit vector.
+func (r Ray) At(t float32) Pt {
+	return r.Origin.Add(r.Direction.Scale(t))
+}
+
+// ScreenPointToRay returns a world-space ray from the near plane through the provided window coordinate, for example
+// to pick the object under the mouse cursor. viewport, view and proj have the same meaning as in [Unproject]. See
+// [Viewport.ScreenPointToRay].
+func ScreenPointToRay(screen Pt2, viewport Vec, view, proj Mat) Ray {
+	return NewViewportFromVec(viewport).ScreenPointToRay(screen, view, proj)
+}
@@ -0,0 +1,28 @@
+package vkm
+
+import "testing"
+
+func TestScreenPointToRay(t *testing.T) {
+	eye := NewPt(3, 4, 5)
+	view := LookAt(eye, NewPt(0, 1, 0), NewVec(0, 1, 0))
+	viewport := Vec{0, 0, 800, 600}
+	target := NewPt(0.5, 1.5, -1)
+
+	for _, proj := range []Mat{
+		PerspectiveDeg(60, 800.0/600.0, 0.1, 100),
+		InvertedDepthPerspective(1, 800.0/600.0, 0.1, 100),
+	} {
+		win := Project(target, view, proj, viewport)
+		ray := ScreenPointToRay(Pt2{win[0], win[1]}, viewport, view, proj)
+
+		// The ray should start at the near plane, 0.1 units in front of the eye, and head away from it
+		if d := eye.VecTo(ray.Origin).Dot(ray.Direction); d <= 0 {
+			t.Errorf("Ray does not point away from the camera! Origin: %+v Direction: %+v", ray.Origin, ray.Direction)
+		}
+
+		dist := ray.Origin.VecTo(target).Length()
+		if res := ray.At(dist); !testApproxPt(res, target, 0.001) {
+			t.Errorf("Ray does not pass through the picked point! Expected: %+v Actual: %+v", target, res)
+		}
+	}
+}
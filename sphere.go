@@ -0,0 +1,160 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Sphere is a bounding sphere, defined by its center and radius. A sphere with a negative radius is empty, and contains
+// nothing.
+type Sphere struct {
+	Center Pt
+	Radius float32
+}
+
+// NewSphere creates a sphere with the provided center and radius.
+func NewSphere(center Pt, radius float32) Sphere {
+	return Sphere{center, radius}
+}
+
+// NewSphereFromPoints returns a sphere containing all points in pts, using Jack Ritter's algorithm from Graphics Gems.
+// The result is not the minimal bounding sphere, but is usually within a few percent of it and is computed in linear
+// time. If pts is empty, the result is an empty sphere with a radius of -1.
+func NewSphereFromPoints(pts []Pt) Sphere {
+	if len(pts) == 0 {
+		return Sphere{Origin(), -1}
+	}
+
+	// Start from an approximate diameter: the point farthest from an arbitrary point, and the point farthest from that
+	farthest := func(from Pt) Pt {
+		rval, d := from, float32(0)
+		for _, p := range pts {
+			if pd := from.VecTo(p).SquareLength(); pd > d {
+				rval, d = p, pd
+			}
+		}
+		return rval
+	}
+	a := farthest(pts[0])
+	b := farthest(a)
+
+	s := Sphere{a.Lerp(b, 0.5), a.VecTo(b).Length() / 2}
+	for _, p := range pts {
+		s = s.Include(p)
+	}
+	return s
+}
+
+// IsEmpty returns true if s has a negative radius.
+func (s Sphere) IsEmpty() bool {
+	return s.Radius < 0
+}
+
+// Include returns the smallest sphere containing both s and p. If p is already inside s, s is returned unchanged.
+func (s Sphere) Include(p Pt) Sphere {
+	if s.IsEmpty() {
+		return Sphere{p, 0}
+	}
+	v := s.Center.VecTo(p)
+	d := v.Length()
+	if d <= s.Radius {
+		return s
+	}
+	// The new sphere spans from the far side of s to p
+	r := (s.Radius + d) / 2
+	return Sphere{s.Center.Add(v.Scale((r - s.Radius) / d)), r}
+}
+
+// Merge returns the smallest sphere containing both s and o.
+func (s Sphere) Merge(o Sphere) Sphere {
+	switch {
+	case o.IsEmpty():
+		return s
+	case s.IsEmpty():
+		return o
+	}
+	v := s.Center.VecTo(o.Center)
+	d := v.Length()
+	switch {
+	case d+o.Radius <= s.Radius:
+		return s
+	case d+s.Radius <= o.Radius:
+		return o
+	}
+	r := (d + s.Radius + o.Radius) / 2
+	return Sphere{s.Center.Add(v.Scale((r - s.Radius) / d)), r}
+}
+
+// ContainsPt returns true if p is inside s or on its surface. An empty sphere contains no points.
+func (s Sphere) ContainsPt(p Pt) bool {
+	if s.IsEmpty() {
+		return false
+	}
+	return s.Center.VecTo(p).SquareLength() <= s.Radius*s.Radius
+}
+
+// IntersectRay returns the distance along r to the first point where it enters s (zero if r starts inside s) and
+// true, or false if r misses s. The distance is measured in units of r's direction vector. A ray never hits an empty
+// sphere.
+func (s Sphere) IntersectRay(r Ray) (float32, bool) {
+	if s.IsEmpty() {
+		return 0, false
+	}
+	oc := s.Center.VecTo(r.Origin)
+	c := oc.SquareLength() - s.Radius*s.Radius
+	if c <= 0 {
+		return 0, true
+	}
+	// Solve |o + td - center|^2 = radius^2 for t, with b halved
+	a := r.Direction.SquareLength()
+	b := oc.Dot(r.Direction)
+	if b >= 0 {
+		// Outside the sphere and pointing away
+		return 0, false
+	}
+	disc := b*b - a*c
+	if disc < 0 {
+		return 0, false
+	}
+	return (-b - math32.Sqrt(disc)) / a, true
+}
+
+// Intersects returns true if s and o overlap or touch. An empty sphere intersects nothing.
+func (s Sphere) Intersects(o Sphere) bool {
+	if s.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+	r := s.Radius + o.Radius
+	return s.Center.VecTo(o.Center).SquareLength() <= r*r
+}
+
+// IntersectsPlane returns true if s touches or crosses pl. pl must be normalized.
+func (s Sphere) IntersectsPlane(pl Plane) bool {
+	if s.IsEmpty() {
+		return false
+	}
+	return math32.Abs(pl.SignedDistance(s.Center)) <= s.Radius
+}
+
+// IntersectsAABB returns true if s and b overlap or touch.
+func (s Sphere) IntersectsAABB(b AABB) bool {
+	if s.IsEmpty() {
+		return false
+	}
+	return s.ContainsPt(b.ClosestPt(s.Center))
+}
+
+// TransformSphere returns a sphere containing s after transformation by m, which is assumed to be affine. Under a
+// non-uniform scale, a sphere becomes an ellipsoid, so the radius is scaled by the largest scale factor in m and the
+// result is a bounding sphere of the true shape. An empty sphere is returned unchanged.
+func (m Mat) TransformSphere(s Sphere) Sphere {
+	if s.IsEmpty() {
+		return s
+	}
+	return Sphere{m.MultP(s.Center), s.Radius * m.maxScale()}
+}
+
+// maxScale returns the largest factor by which the upper 3x3 of m can stretch a vector: its largest singular value.
+// For a matrix without shear, this is the largest of the axis scale factors.
+func (m Mat) maxScale() float32 {
+	a := m.UpperLeft3()
+	values, _ := a.Transpose().MultM(a).symmetricEigen()
+	return math32.Sqrt(math32.Max(values[0], math32.Max(values[1], values[2])))
+}
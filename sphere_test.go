@@ -0,0 +1,134 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestNewSphereFromPoints(t *testing.T) {
+	pts := []Pt{
+		NewPt(1, 0, 0), NewPt(-1, 0, 0), NewPt(0, 1, 0), NewPt(0, -1, 0), NewPt(0, 0, 1), NewPt(0, 0, -1),
+		NewPt(0.5, 0.5, 0.5), NewPt(3, 2, -1),
+	}
+	s := NewSphereFromPoints(pts)
+	for _, p := range pts {
+		if s.Center.VecTo(p).Length() > s.Radius+0.00001 {
+			t.Errorf("Bounding sphere %+v did not contain %+v!", s, p)
+		}
+	}
+
+	// The two farthest points are (-1,0,0) and (3,2,-1); the minimal sphere can't be smaller than half that distance
+	min := NewPt(-1, 0, 0).VecTo(NewPt(3, 2, -1)).Length() / 2
+	if s.Radius < min || s.Radius > min*1.2 {
+		t.Errorf("Bounding sphere radius was not close to minimal! Expected about: %v Actual: %v", min, s.Radius)
+	}
+
+	if e := NewSphereFromPoints(nil); !e.IsEmpty() {
+		t.Errorf("Sphere from no points was not empty! Actual: %+v", e)
+	}
+}
+
+func TestSphereMerge(t *testing.T) {
+	a := NewSphere(NewPt(0, 0, 0), 1)
+	b := NewSphere(NewPt(4, 0, 0), 1)
+
+	exp := NewSphere(NewPt(2, 0, 0), 3)
+	if res := a.Merge(b); !res.Center.EqualTo(exp.Center) || math32.Abs(res.Radius-exp.Radius) > 0.00001 {
+		t.Errorf("Merge failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	inner := NewSphere(NewPt(0.5, 0, 0), 0.25)
+	if res := a.Merge(inner); res != a {
+		t.Errorf("Merging a contained sphere changed the result! Expected: %+v Actual: %+v", a, res)
+	}
+	if res := inner.Merge(a); res != a {
+		t.Errorf("Merging into a contained sphere did not return the outer sphere! Expected: %+v Actual: %+v", a, res)
+	}
+	if res := NewSphereFromPoints(nil).Merge(a); res != a {
+		t.Errorf("Merging into an empty sphere failed! Expected: %+v Actual: %+v", a, res)
+	}
+}
+
+func TestSphereIntersections(t *testing.T) {
+	s := NewSphere(NewPt(0, 0, -5), 1)
+
+	if d, ok := s.IntersectRay(NewRay(Origin(), NewVec(0, 0, -1))); !ok || math32.Abs(d-4) > 0.00001 {
+		t.Errorf("Ray toward the sphere missed! Expected: 4, true Actual: %v, %v", d, ok)
+	}
+	if _, ok := s.IntersectRay(NewRay(Origin(), NewVec(0, 0, 1))); ok {
+		t.Errorf("Ray pointing away from the sphere hit!")
+	}
+	if _, ok := s.IntersectRay(NewRay(NewPt(1.5, 0, 0), NewVec(0, 0, -1))); ok {
+		t.Errorf("Ray passing beside the sphere hit!")
+	}
+	if d, ok := s.IntersectRay(NewRay(NewPt(0, 0.5, -5), NewVec(1, 0, 0))); !ok || d != 0 {
+		t.Errorf("Ray starting inside the sphere failed! Expected: 0, true Actual: %v, %v", d, ok)
+	}
+
+	if !s.Intersects(NewSphere(NewPt(0, 1.5, -5), 0.5)) || s.Intersects(NewSphere(NewPt(0, 2, -5), 0.5)) {
+		t.Errorf("Sphere-sphere intersection failed!")
+	}
+
+	if !s.IntersectsPlane(NewPlane(NewPt(0, 0, -5.5), UnitVecZ())) || s.IntersectsPlane(NewPlane(Origin(), UnitVecZ())) {
+		t.Errorf("Sphere-plane intersection failed!")
+	}
+
+	b := NewAABB(NewPt(0.5, 0.5, -4.5), NewPt(2, 2, 0))
+	if !s.IntersectsAABB(b) {
+		t.Errorf("Sphere overlapping a box corner did not intersect!")
+	}
+	b = NewAABB(NewPt(0.8, 0.8, -4.5), NewPt(2, 2, 0))
+	if s.IntersectsAABB(b) {
+		t.Errorf("Sphere near, but not touching, a box corner intersected!")
+	}
+}
+
+func TestTransformSphere(t *testing.T) {
+	s := NewSphere(NewPt(1, 0, 0), 2)
+
+	m := NewMatScale(NewVec(1, 3, 2)).RotateZDeg(45).Translate(NewVec(0, 0, 5))
+	res := m.TransformSphere(s)
+	if exp := m.MultP(s.Center); !res.Center.EqualTo(exp) {
+		t.Errorf("TransformSphere center failed! Expected: %+v Actual: %+v", exp, res.Center)
+	}
+	if math32.Abs(res.Radius-6) > 0.0001 {
+		t.Errorf("TransformSphere did not use the largest scale! Expected: 6 Actual: %v", res.Radius)
+	}
+
+	// A sheared unit sphere: every transformed surface point must be inside the result
+	sh := Mat{{1, 0, 0, 0}, {2, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	unit := NewSphere(Origin(), 1)
+	res = sh.TransformSphere(unit)
+	for i := 0; i < 64; i++ {
+		a := float32(i) * math32.Pi / 32
+		p := sh.MultP(NewPt(math32.Cos(a), math32.Sin(a), 0))
+		if res.Center.VecTo(p).Length() > res.Radius+0.0001 {
+			t.Errorf("Sheared sphere point %+v was outside %+v!", p, res)
+		}
+	}
+}
+
+func TestEmptySphere(t *testing.T) {
+	e := NewSphereFromPoints(nil)
+	s := NewSphere(Origin(), 2)
+
+	if e.ContainsPt(Origin()) || e.ContainsPt(NewPt(0.5, 0, 0)) {
+		t.Errorf("Empty sphere contained a point!")
+	}
+	if _, ok := e.IntersectRay(NewRay(NewPt(0.5, 0, 0), UnitVecX())); ok {
+		t.Errorf("Ray starting near an empty sphere hit it!")
+	}
+	if e.Intersects(s) || s.Intersects(e) {
+		t.Errorf("Empty sphere intersected another sphere!")
+	}
+	if e.IntersectsPlane(NewPlane(Origin(), UnitVecZ())) {
+		t.Errorf("Empty sphere intersected a plane!")
+	}
+	if e.IntersectsAABB(NewAABB(NewPt(-1, -1, -1), NewPt(1, 1, 1))) {
+		t.Errorf("Empty sphere intersected a box!")
+	}
+	if res := NewMatScale(NewVec(3, 3, 3)).Translate(NewVec(1, 2, 3)).TransformSphere(e); !res.IsEmpty() {
+		t.Errorf("Transformed empty sphere was not empty! Actual: %+v", res)
+	}
+}
@@ -0,0 +1,107 @@
+package vkm
+
+// Transform is a compact translation, rotation and scale (TRS) transformation. It is an alternative to carrying a full
+// Mat around a scene graph: the components can be edited and interpolated independently, and the matrix is only
+// built when needed, typically just before upload:
+//
+//	m := xform.AsMat()
+//	copy(buffer, m.AsBytes())
+//
+// A Transform applies scale first, then rotation, then translation, matching
+//
+//	Identity().Scale(s).RotateQuat(r).Translate(t)
+type Transform struct {
+	Translation Vec
+	Rotation    Quat
+	Scale       Vec
+}
+
+// NewTransform creates a Transform from the provided components. Note that the fourth component of translation and
+// scale is ignored.
+func NewTransform(translation Vec, rotation Quat, scale Vec) Transform {
+	return Transform{translation, rotation, scale}
+}
+
+// IdentityTransform returns a Transform that leaves points and vectors unchanged.
+func IdentityTransform() Transform {
+	return Transform{ZeroVec(), IdentityQuat(), NewVec(1, 1, 1)}
+}
+
+// NewTransformFromMat decomposes m into a Transform. Any shear or perspective in m is discarded. See [Mat.Decompose].
+// If m is singular, the result is the zero Transform, which is not valid; use [NewTransformFromMatChecked] if m may be
+// singular.
+func NewTransformFromMat(m Mat) Transform {
+	t, r, s := m.Decompose()
+	return Transform{t, r, s}
+}
+
+// NewTransformFromMatChecked is identical to [NewTransformFromMat], but returns ErrSingularMatrix if m cannot be
+// decomposed.
+func NewTransformFromMatChecked(m Mat) (Transform, error) {
+	t, r, s, err := m.DecomposeChecked()
+	if err != nil {
+		return Transform{}, err
+	}
+	return Transform{t, r, s}, nil
+}
+
+// AsMat builds the transformation matrix for t.
+func (t Transform) AsMat() Mat {
+	m := t.Rotation.AsMat()
+	for i := 0; i < 3; i++ {
+		m[i] = m[i].Scale(t.Scale[i])
+	}
+	m[3] = Vec{t.Translation[0], t.Translation[1], t.Translation[2], 1}
+	return m
+}
+
+// MultP applies t to the point p. Points are scaled, rotated and then translated.
+func (t Transform) MultP(p Pt) Pt {
+	tr := NewVec(t.Translation[0], t.Translation[1], t.Translation[2]).Scale(p[3])
+	return t.Rotation.RotateP(Pt{p[0] * t.Scale[0], p[1] * t.Scale[1], p[2] * t.Scale[2], p[3]}).Add(tr)
+}
+
+// MultV applies t to the vector v. Vectors are scaled and rotated, but, as with a matrix multiplication on a vector
+// with w = 0, they are not translated.
+func (t Transform) MultV(v Vec) Vec {
+	return t.Rotation.RotateV(Vec{v[0] * t.Scale[0], v[1] * t.Scale[1], v[2] * t.Scale[2], v[3]})
+}
+
+// Mult composes t with child, returning the transform that applies child first and then t. Use this to compute the
+// world transform of a node from its parent's world transform:
+//
+//	world := parentWorld.Mult(local)
+//
+// A rotated, non-uniform scale cannot always be represented as a Transform, so the result is only exact when the scale
+// of t is uniform or child has no rotation. Use [Transform.AsMat] and [Mat.MultM] if you need an exact result.
+func (t Transform) Mult(child Transform) Transform {
+	return Transform{
+		Translation: Origin().VecTo(t.MultP(NewPt(child.Translation[0], child.Translation[1], child.Translation[2]))),
+		Rotation:    t.Rotation.Mult(child.Rotation),
+		Scale:       NewVec(t.Scale[0]*child.Scale[0], t.Scale[1]*child.Scale[1], t.Scale[2]*child.Scale[2]),
+	}
+}
+
+// Inverse returns the transform that reverses t. As with [Transform.Mult], the result is only exact if the scale of t
+// is uniform.
+func (t Transform) Inverse() Transform {
+	s := NewVec(1/t.Scale[0], 1/t.Scale[1], 1/t.Scale[2])
+	r := t.Rotation.Conjugate()
+	tr := r.RotateV(t.Translation.Invert())
+
+	return Transform{
+		Translation: NewVec(tr[0]*s[0], tr[1]*s[1], tr[2]*s[2]),
+		Rotation:    r,
+		Scale:       s,
+	}
+}
+
+// Lerp interpolates between t and u, returning t when f is 0 and u when f is 1. Translation and scale are linearly
+// interpolated, and rotation is spherically interpolated. See [Quat.Slerp].
+func (t Transform) Lerp(u Transform, f float32) Transform {
+	return Transform{
+		Translation: t.Translation.Lerp(u.Translation, f),
+		Rotation:    t.Rotation.Slerp(u.Rotation, f),
+		Scale:       t.Scale.Lerp(u.Scale, f),
+	}
+}
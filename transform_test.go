@@ -0,0 +1,64 @@
+package vkm
+
+import "testing"
+
+func TestTransformAsMat(t *testing.T) {
+	xf := NewTransform(NewVec(1, 2, 3), NewQuatDeg(NewVec(1, 1, 0).Normalize(), 40), NewVec(2, 3, 4))
+	exp := Identity().Scale(xf.Scale).RotateQuat(xf.Rotation).Translate(xf.Translation)
+	m := xf.AsMat()
+
+	if !m.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Transform.AsMat failed! Expected: %+v Actual: %+v", exp, m)
+	}
+
+	p := NewPt(-1, 5, 2)
+	if exp, res := m.MultP(p), xf.MultP(p); !res.EqualTo(exp) {
+		t.Errorf("Transform.MultP did not match the matrix! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	v := NewVec(-1, 5, 2)
+	if exp, res := m.MultV(v), xf.MultV(v); !Pt(res).EqualTo(Pt(exp)) {
+		t.Errorf("Transform.MultV did not match the matrix! Expected: %+v Actual: %+v", exp, res)
+	}
+	if res := xf.MultV(v); res[3] != 0 {
+		t.Errorf("Transform.MultV applied translation to a vector! Actual: %+v", res)
+	}
+
+	back := NewTransformFromMat(m).AsMat()
+	if !back.ApproximatelyEquals(m, 0.0001) {
+		t.Errorf("NewTransformFromMat round trip failed! Expected: %+v Actual: %+v", m, back)
+	}
+	if checked, err := NewTransformFromMatChecked(m); err != nil || checked != NewTransformFromMat(m) {
+		t.Errorf("NewTransformFromMatChecked did not match NewTransformFromMat! Actual: %+v, %v", checked, err)
+	}
+	if _, err := NewTransformFromMatChecked(NewMatScale(NewVec(0, 1, 1))); err != ErrSingularMatrix {
+		t.Errorf("NewTransformFromMatChecked of a singular matrix did not fail! Expected: %v Actual: %v", ErrSingularMatrix, err)
+	}
+}
+
+func TestTransformMult(t *testing.T) {
+	parent := NewTransform(NewVec(10, 0, 0), NewQuatDeg(UnitVecY(), 90), NewVec(2, 2, 2))
+	child := NewTransform(NewVec(0, 1, 1), NewQuatDeg(UnitVecX(), 30), NewVec(1, 0.5, 3))
+
+	exp := parent.AsMat().MultM(child.AsMat())
+	res := parent.Mult(child).AsMat()
+	if !res.ApproximatelyEquals(exp, 0.0001) {
+		t.Errorf("Transform.Mult did not match matrix composition! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	id := parent.Mult(parent.Inverse()).AsMat()
+	if !id.ApproximatelyEquals(Identity(), 0.0001) {
+		t.Errorf("Transform x Transform.Inverse() was not the identity! Actual: %+v", id)
+	}
+}
+
+func TestTransformLerp(t *testing.T) {
+	a := NewTransform(NewVec(0, 0, 0), NewQuatDeg(UnitVecZ(), 0), NewVec(1, 1, 1))
+	b := NewTransform(NewVec(4, 0, 0), NewQuatDeg(UnitVecZ(), 90), NewVec(3, 3, 3))
+
+	exp := NewTransform(NewVec(2, 0, 0), NewQuatDeg(UnitVecZ(), 45), NewVec(2, 2, 2)).AsMat()
+	res := a.Lerp(b, 0.5).AsMat()
+	if !res.ApproximatelyEquals(exp, 0.00001) {
+		t.Errorf("Transform.Lerp failed! Expected: %+v Actual: %+v", exp, res)
+	}
+}
@@ -0,0 +1,205 @@
+package vkm
+
+import "github.com/chewxy/math32"
+
+// Triangle is a triangle defined by its three vertices. As with the other types in this package, it is fundamentally an
+// array, so vertices can be addressed directly as tri[0], tri[1] and tri[2]. The front face is the side from which the
+// vertices appear in counterclockwise order, matching [NewPlaneFromPoints].
+type Triangle [3]Pt
+
+// NewTriangle creates a triangle from the provided vertices.
+func NewTriangle(a, b, c Pt) Triangle {
+	return Triangle{a, b, c}
+}
+
+// cross returns the (unnormalized) cross product of tri's edges, whose length is twice the triangle's area.
+func (tri Triangle) cross() Vec {
+	return tri[0].VecTo(tri[1]).Cross(tri[0].VecTo(tri[2]))
+}
+
+// Normal returns the unit normal of tri's front face. A degenerate triangle has no normal, and the result is NaN.
+func (tri Triangle) Normal() Vec {
+	return tri.cross().Normalize()
+}
+
+// Area returns the area of tri.
+func (tri Triangle) Area() float32 {
+	return tri.cross().Length() / 2
+}
+
+// Plane returns the plane containing tri, facing the same direction as its front face.
+func (tri Triangle) Plane() Plane {
+	return NewPlaneFromPoints(tri[0], tri[1], tri[2])
+}
+
+// Barycentric returns the barycentric coordinates of p with respect to tri: the weights of tri[0], tri[1] and tri[2]
+// that sum to one and reproduce p. If p is not in the plane of tri, the coordinates of its projection onto the plane are
+// returned. p is inside the triangle when all three weights are in the range [0..1].
+func (tri Triangle) Barycentric(p Pt) (u, v, w float32) {
+	v0, v1, v2 := tri[0].VecTo(tri[1]), tri[0].VecTo(tri[2]), tri[0].VecTo(p)
+	d00, d01, d11 := v0.Dot(v0), v0.Dot(v1), v1.Dot(v1)
+	d20, d21 := v2.Dot(v0), v2.Dot(v1)
+	denom := d00*d11 - d01*d01
+	v = (d11*d20 - d01*d21) / denom
+	w = (d00*d21 - d01*d20) / denom
+	return 1 - v - w, v, w
+}
+
+// IntersectRay tests r against tri with the Möller–Trumbore algorithm. If the ray hits, IntersectRay returns the
+// distance along the ray (in units of r's direction vector), the barycentric weights v and w of tri[1] and tri[2] at the
+// hit point, and true. The weight of tri[0] is 1 - v - w. If cullBackface is true, rays striking the back of the
+// triangle miss.
+func (tri Triangle) IntersectRay(r Ray, cullBackface bool) (t, v, w float32, ok bool) {
+	const eps = 0.0000001
+	e1, e2 := tri[0].VecTo(tri[1]), tri[0].VecTo(tri[2])
+	pv := r.Direction.Cross(e2)
+	det := e1.Dot(pv)
+	// det is positive when the ray hits the front face
+	if cullBackface && det < eps || math32.Abs(det) < eps {
+		return 0, 0, 0, false
+	}
+	inv := 1 / det
+
+	tv := tri[0].VecTo(r.Origin)
+	v = tv.Dot(pv) * inv
+	if v < 0 || v > 1 {
+		return 0, 0, 0, false
+	}
+	qv := tv.Cross(e1)
+	w = r.Direction.Dot(qv) * inv
+	if w < 0 || v+w > 1 {
+		return 0, 0, 0, false
+	}
+	t = e2.Dot(qv) * inv
+	if t < 0 {
+		return 0, 0, 0, false
+	}
+	return t, v, w, true
+}
+
+// ClosestPt returns the point on tri (including its interior) that is closest to p. This follows Christer Ericson's
+// Real-Time Collision Detection, section 5.1.5.
+func (tri Triangle) ClosestPt(p Pt) Pt {
+	a, b, c := tri[0], tri[1], tri[2]
+	ab, ac, ap := a.VecTo(b), a.VecTo(c), a.VecTo(p)
+
+	// Vertex region outside a
+	d1, d2 := ab.Dot(ap), ac.Dot(ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+	// Vertex region outside b
+	bp := b.VecTo(p)
+	d3, d4 := ab.Dot(bp), ac.Dot(bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+	// Edge region of ab
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return a.Add(ab.Scale(d1 / (d1 - d3)))
+	}
+	// Vertex region outside c
+	cp := c.VecTo(p)
+	d5, d6 := ab.Dot(cp), ac.Dot(cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+	// Edge region of ac
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return a.Add(ac.Scale(d2 / (d2 - d6)))
+	}
+	// Edge region of bc
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return b.Add(b.VecTo(c).Scale((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+	// Inside the face
+	denom := 1 / (va + vb + vc)
+	return a.Add(ab.Scale(vb * denom)).Add(ac.Scale(vc * denom))
+}
+
+// project returns the interval covered by tri when projected onto axis.
+func (tri Triangle) project(axis Vec) (min, max float32) {
+	min, max = math32.Inf(1), math32.Inf(-1)
+	for _, p := range tri {
+		d := axis.Dot(Vec(p))
+		min, max = math32.Min(min, d), math32.Max(max, d)
+	}
+	return
+}
+
+// IntersectsAABB returns true if tri and b overlap or touch, using Tomas Akenine-Möller's separating axis test.
+func (tri Triangle) IntersectsAABB(b AABB) bool {
+	// Move the box to the origin
+	c, e := b.Center(), b.Extents()
+	var v [3]Vec
+	for i := range tri {
+		v[i] = c.VecTo(tri[i])
+	}
+	edges := [3]Vec{v[1].Sub(v[0]), v[2].Sub(v[1]), v[0].Sub(v[2])}
+
+	// The nine cross products of the triangle's edges with the box axes
+	for _, edge := range edges {
+		for i := 0; i < 3; i++ {
+			var axis Vec
+			axis[(i+1)%3], axis[(i+2)%3] = -edge[(i+2)%3], edge[(i+1)%3]
+			r := e[0]*math32.Abs(axis[0]) + e[1]*math32.Abs(axis[1]) + e[2]*math32.Abs(axis[2])
+			p0, p1, p2 := axis.Dot(v[0]), axis.Dot(v[1]), axis.Dot(v[2])
+			if math32.Max(p0, math32.Max(p1, p2)) < -r || math32.Min(p0, math32.Min(p1, p2)) > r {
+				return false
+			}
+		}
+	}
+
+	// The box's face normals, i.e. the triangle's bounds against the box
+	for i := 0; i < 3; i++ {
+		if math32.Max(v[0][i], math32.Max(v[1][i], v[2][i])) < -e[i] || math32.Min(v[0][i], math32.Min(v[1][i], v[2][i])) > e[i] {
+			return false
+		}
+	}
+
+	// The triangle's normal
+	n := edges[0].Cross(edges[1])
+	r := e[0]*math32.Abs(n[0]) + e[1]*math32.Abs(n[1]) + e[2]*math32.Abs(n[2])
+	return math32.Abs(n.Dot(v[0])) <= r
+}
+
+// Intersects returns true if tri and o overlap or touch, including when they are coplanar. This uses the separating
+// axis theorem: the two face normals, the nine cross products of edge pairs, and, for the coplanar case, the in-plane
+// normals of every edge.
+func (tri Triangle) Intersects(o Triangle) bool {
+	ea := [3]Vec{tri[0].VecTo(tri[1]), tri[1].VecTo(tri[2]), tri[2].VecTo(tri[0])}
+	eb := [3]Vec{o[0].VecTo(o[1]), o[1].VecTo(o[2]), o[2].VecTo(o[0])}
+	na, nb := ea[0].Cross(ea[1]), eb[0].Cross(eb[1])
+
+	// Parallel edges produce a zero axis, which cannot separate anything. Whether an axis is "zero" depends on the size
+	// of the vectors that made it, so compare each axis against the product of their squared lengths, i.e. skip any axis
+	// where the sine of the angle between them is negligible.
+	axes := make([]Vec, 0, 17)
+	add := func(u, v Vec) {
+		axis := u.Cross(v)
+		if axis.SquareLength() > 1e-10*u.SquareLength()*v.SquareLength() {
+			axes = append(axes, axis)
+		}
+	}
+	add(ea[0], ea[1])
+	add(eb[0], eb[1])
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			add(ea[i], eb[j])
+		}
+		add(na, ea[i])
+		add(nb, eb[i])
+	}
+
+	for _, axis := range axes {
+		amin, amax := tri.project(axis)
+		bmin, bmax := o.project(axis)
+		if amax < bmin || bmax < amin {
+			return false
+		}
+	}
+	return true
+}
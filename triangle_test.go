@@ -0,0 +1,137 @@
+package vkm
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestTriangleBasics(t *testing.T) {
+	tri := NewTriangle(NewPt(0, 0, 0), NewPt(2, 0, 0), NewPt(0, 2, 0))
+
+	if n := tri.Normal(); !Pt(n).EqualTo(Pt(UnitVecZ())) {
+		t.Errorf("Normal failed! Expected: %+v Actual: %+v", UnitVecZ(), n)
+	}
+	if a := tri.Area(); a != 2 {
+		t.Errorf("Area failed! Expected: 2 Actual: %v", a)
+	}
+	if pl := tri.Plane(); pl.SignedDistance(NewPt(5, 5, 1)) != 1 {
+		t.Errorf("Plane did not face the same way as the triangle! Actual: %+v", pl)
+	}
+
+	u, v, w := tri.Barycentric(NewPt(0.5, 1, 3))
+	if math32.Abs(u-0.25) > 0.00001 || math32.Abs(v-0.25) > 0.00001 || math32.Abs(w-0.5) > 0.00001 {
+		t.Errorf("Barycentric failed! Expected: 0.25, 0.25, 0.5 Actual: %v, %v, %v", u, v, w)
+	}
+	if u, v, w := tri.Barycentric(tri[1]); u != 0 || v != 1 || w != 0 {
+		t.Errorf("Barycentric of a vertex failed! Expected: 0, 1, 0 Actual: %v, %v, %v", u, v, w)
+	}
+}
+
+func TestTriangleIntersectRay(t *testing.T) {
+	tri := NewTriangle(NewPt(0, 0, 0), NewPt(2, 0, 0), NewPt(0, 2, 0))
+
+	front := NewRay(NewPt(0.5, 1, 3), UnitVecZ().Invert())
+	d, v, w, ok := tri.IntersectRay(front, true)
+	if !ok || math32.Abs(d-3) > 0.00001 || math32.Abs(v-0.25) > 0.00001 || math32.Abs(w-0.5) > 0.00001 {
+		t.Errorf("Front face hit failed! Expected: 3, 0.25, 0.5, true Actual: %v, %v, %v, %v", d, v, w, ok)
+	}
+
+	back := NewRay(NewPt(0.5, 1, -3), UnitVecZ())
+	if _, _, _, ok := tri.IntersectRay(back, false); !ok {
+		t.Errorf("Back face hit failed without culling!")
+	}
+	if _, _, _, ok := tri.IntersectRay(back, true); ok {
+		t.Errorf("Back face hit was not culled!")
+	}
+
+	if _, _, _, ok := tri.IntersectRay(NewRay(NewPt(1.5, 1.5, 3), UnitVecZ().Invert()), false); ok {
+		t.Errorf("Ray outside the triangle hit!")
+	}
+	if _, _, _, ok := tri.IntersectRay(NewRay(NewPt(0.5, 1, 3), UnitVecZ()), false); ok {
+		t.Errorf("Ray pointing away from the triangle hit!")
+	}
+	if _, _, _, ok := tri.IntersectRay(NewRay(NewPt(-1, 0.5, 0), UnitVecX()), false); ok {
+		t.Errorf("Ray in the triangle's plane hit!")
+	}
+}
+
+func TestTriangleClosestPt(t *testing.T) {
+	tri := NewTriangle(NewPt(0, 0, 0), NewPt(2, 0, 0), NewPt(0, 2, 0))
+
+	tests := []struct {
+		p, exp Pt
+	}{
+		{NewPt(0.5, 0.5, 3), NewPt(0.5, 0.5, 0)}, // face
+		{NewPt(-1, -1, 1), NewPt(0, 0, 0)},       // vertex a
+		{NewPt(3, -1, 0), NewPt(2, 0, 0)},        // vertex b
+		{NewPt(-0.5, 4, 0), NewPt(0, 2, 0)},      // vertex c
+		{NewPt(1, -2, 0), NewPt(1, 0, 0)},        // edge ab
+		{NewPt(-2, 1, 1), NewPt(0, 1, 0)},        // edge ac
+		{NewPt(2, 2, 0), NewPt(1, 1, 0)},         // edge bc
+	}
+	for _, tc := range tests {
+		if res := tri.ClosestPt(tc.p); !res.EqualTo(tc.exp) {
+			t.Errorf("ClosestPt to %+v failed! Expected: %+v Actual: %+v", tc.p, tc.exp, res)
+		}
+	}
+}
+
+func TestTriangleIntersectsAABB(t *testing.T) {
+	b := NewAABB(NewPt(0, 0, 0), NewPt(1, 1, 1))
+
+	if !NewTriangle(NewPt(-1, 0.5, -1), NewPt(2, 0.5, -1), NewPt(0.5, 0.5, 2)).IntersectsAABB(b) {
+		t.Errorf("Triangle slicing through the box did not intersect!")
+	}
+	if !NewTriangle(NewPt(0.2, 0.2, 0.2), NewPt(0.4, 0.2, 0.2), NewPt(0.2, 0.4, 0.2)).IntersectsAABB(b) {
+		t.Errorf("Triangle inside the box did not intersect!")
+	}
+	if NewTriangle(NewPt(2, 0, 0), NewPt(3, 0, 0), NewPt(2, 1, 0)).IntersectsAABB(b) {
+		t.Errorf("Triangle beside the box intersected!")
+	}
+	// Passes just beyond the corner at (1, 1, 1), although the bounds overlap
+	if NewTriangle(NewPt(3.1, 0, 0), NewPt(0, 3.1, 0), NewPt(0, 0, 3.1)).IntersectsAABB(b) {
+		t.Errorf("Triangle beyond the box corner intersected!")
+	}
+	// A vertical sliver running diagonally past the edge at x = 1, y = 0, and one cutting through it
+	if NewTriangle(NewPt(0.5, -0.6, -1), NewPt(1.6, 0.5, -1), NewPt(1.6, 0.5, 2)).IntersectsAABB(b) {
+		t.Errorf("Triangle beyond the box edge intersected!")
+	}
+	if !NewTriangle(NewPt(0.5, -0.4, -1), NewPt(1.4, 0.5, -1), NewPt(1.4, 0.5, 2)).IntersectsAABB(b) {
+		t.Errorf("Triangle cutting the box edge did not intersect!")
+	}
+}
+
+func TestTriangleIntersects(t *testing.T) {
+	tri := NewTriangle(NewPt(0, 0, 0), NewPt(2, 0, 0), NewPt(0, 2, 0))
+
+	if !tri.Intersects(NewTriangle(NewPt(0.5, 0.5, -1), NewPt(0.5, 0.5, 1), NewPt(3, 3, 0))) {
+		t.Errorf("Triangle piercing the face did not intersect!")
+	}
+	if tri.Intersects(NewTriangle(NewPt(1.5, 1.5, -1), NewPt(1.5, 1.5, 1), NewPt(3, 3, 0))) {
+		t.Errorf("Triangle passing beyond the hypotenuse intersected!")
+	}
+	if tri.Intersects(NewTriangle(NewPt(0, 0, 1), NewPt(2, 0, 1), NewPt(0, 2, 1))) {
+		t.Errorf("Parallel triangles intersected!")
+	}
+	// Coplanar cases
+	if !tri.Intersects(NewTriangle(NewPt(1, 1, 0), NewPt(3, 1, 0), NewPt(1, 3, 0))) {
+		t.Errorf("Overlapping coplanar triangles did not intersect!")
+	}
+	if tri.Intersects(NewTriangle(NewPt(1.5, 1.5, 0), NewPt(3, 1.5, 0), NewPt(1.5, 3, 0))) {
+		t.Errorf("Separate coplanar triangles intersected!")
+	}
+
+	// Tiny triangles must not lose their separating axes to a size-dependent cutoff
+	const s = 0.0005
+	small := NewTriangle(NewPt(0, 0, 0), NewPt(s, 0, 0), NewPt(0, s, 0))
+	if small.Intersects(NewTriangle(NewPt(10, 0, 0), NewPt(10+s, 0, 0), NewPt(10, s, 0))) {
+		t.Errorf("Small triangles 10 units apart intersected!")
+	}
+	if small.Intersects(NewTriangle(NewPt(0, 0, s), NewPt(s, 0, s), NewPt(0, s, s))) {
+		t.Errorf("Small parallel triangles intersected!")
+	}
+	if !small.Intersects(NewTriangle(NewPt(s/4, s/4, -s), NewPt(s/4, s/4, s), NewPt(s, s, 0))) {
+		t.Errorf("Small triangle piercing the face did not intersect!")
+	}
+}
@@ -90,6 +90,11 @@ func (v Vec) Add(u Vec) Vec {
 	return [4]float32{v[0] + u[0], v[1] + u[1], v[2] + u[2], 0.0}
 }
 
+// add4 returns the sum of all four components of v and u, unlike Add which clamps w to zero.
+func (v Vec) add4(u Vec) Vec {
+	return Vec{v[0] + u[0], v[1] + u[1], v[2] + u[2], v[3] + u[3]}
+}
+
 // Sub returns the difference between two vectors
 func (v Vec3) Sub(u Vec3) Vec3 {
 	return [3]float32{v[0] - u[0], v[1] - u[1], v[2] - u[2]}
@@ -100,6 +105,11 @@ func (v Vec) Sub(u Vec) Vec {
 	return [4]float32{v[0] - u[0], v[1] - u[1], v[2] - u[2], 0.0}
 }
 
+// sub4 returns the difference of all four components of v and u, unlike Sub which clamps w to zero.
+func (v Vec) sub4(u Vec) Vec {
+	return Vec{v[0] - u[0], v[1] - u[1], v[2] - u[2], v[3] - u[3]}
+}
+
 // Invert returns a Vec3 of the same magnitude, pointed in the opposite direction
 func (v Vec3) Invert() Vec3 {
 	return [3]float32{-v[0], -v[1], -v[2]}
@@ -127,6 +137,15 @@ func (v Vec) Cross(u Vec) Vec {
 	)
 }
 
+// Cross returns the cross product of v and u
+func (v Vec3) Cross(u Vec3) Vec3 {
+	return Vec3{
+		v[1]*u[2] - v[2]*u[1],
+		v[2]*u[0] - v[0]*u[2],
+		v[0]*u[1] - v[1]*u[0],
+	}
+}
+
 // Dot returns the dot product of v and u. Note that the w component is ignored, though it
 // should be zero for any homogenous vector
 func (v Vec) Dot(u Vec) float32 {
@@ -197,3 +216,19 @@ func (v Vec3) Scale(factor float32) Vec3 {
 func (v Vec2) Scale(factor float32) Vec2 {
 	return Vec2{v[0] * factor, v[1] * factor}
 }
+
+// Lerp linearly interpolates between v and u, returning v when t is 0 and u when t is 1. All four components are
+// interpolated.
+func (v Vec) Lerp(u Vec, t float32) Vec {
+	return Vec{v[0] + (u[0]-v[0])*t, v[1] + (u[1]-v[1])*t, v[2] + (u[2]-v[2])*t, v[3] + (u[3]-v[3])*t}
+}
+
+// Lerp linearly interpolates between v and u, returning v when t is 0 and u when t is 1.
+func (v Vec3) Lerp(u Vec3, t float32) Vec3 {
+	return Vec3{v[0] + (u[0]-v[0])*t, v[1] + (u[1]-v[1])*t, v[2] + (u[2]-v[2])*t}
+}
+
+// Lerp linearly interpolates between v and u, returning v when t is 0 and u when t is 1.
+func (v Vec2) Lerp(u Vec2, t float32) Vec2 {
+	return Vec2{v[0] + (u[0]-v[0])*t, v[1] + (u[1]-v[1])*t}
+}
@@ -31,3 +31,31 @@ func TestAsPt(t *testing.T) {
 		}
 	}
 }
+
+func TestLerp(t *testing.T) {
+	v := NewVec(1, 2, 3)
+	u := NewVec(3, 6, -1)
+
+	if res := v.Lerp(u, 0); res != v {
+		t.Errorf("Lerp at t=0 failed! Expected: %+v Actual: %+v", v, res)
+	}
+	if res := v.Lerp(u, 1); res != u {
+		t.Errorf("Lerp at t=1 failed! Expected: %+v Actual: %+v", u, res)
+	}
+	if exp, res := NewVec(2, 4, 1), v.Lerp(u, 0.5); res != exp {
+		t.Errorf("Lerp at t=0.5 failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	p := NewPt(0, 0, 0)
+	q := NewPt(4, -4, 8)
+	if exp, res := NewPt(1, -1, 2), p.Lerp(q, 0.25); !res.EqualTo(exp) {
+		t.Errorf("Pt.Lerp failed! Expected: %+v Actual: %+v", exp, res)
+	}
+
+	if exp, res := (Pt2{1, 1}), (Pt2{0, 0}).Lerp(Pt2{2, 2}, 0.5); res != exp {
+		t.Errorf("Pt2.Lerp failed! Expected: %+v Actual: %+v", exp, res)
+	}
+	if exp, res := (Vec3{1, 1, 1}), (Vec3{0, 0, 0}).Lerp(Vec3{2, 2, 2}, 0.5); res != exp {
+		t.Errorf("Vec3.Lerp failed! Expected: %+v Actual: %+v", exp, res)
+	}
+}
@@ -0,0 +1,101 @@
+package vkm
+
+// Viewport mirrors VkViewport, describing the mapping from normalized device coordinates (NDC) to framebuffer pixels.
+// X and Y are the top left corner of the viewport, in pixels, and MinDepth and MaxDepth are the depth range.
+//
+// Height may be negative, as allowed by Vulkan 1.1 (and VK_KHR_maintenance1). With Y set to the bottom of the viewport
+// and a negative Height, the Y axis is flipped so that positive NDC Y points up the screen, as in OpenGL. All of the
+// functions below follow the same formula as Vulkan and handle a negative Height correctly.
+type Viewport struct {
+	X, Y, Width, Height float32
+	MinDepth, MaxDepth  float32
+}
+
+// NewViewport creates a viewport with the provided bounds and a depth range of [0..1].
+func NewViewport(x, y, width, height float32) Viewport {
+	return Viewport{x, y, width, height, 0, 1}
+}
+
+// NewViewportFromVec creates a viewport from a Vec holding {x, y, width, height}, as accepted by [Project],
+// [Unproject] and [ScreenPointToRay], with a depth range of [0..1].
+func NewViewportFromVec(v Vec) Viewport {
+	return NewViewport(v[0], v[1], v[2], v[3])
+}
+
+// NDCToWindow returns the matrix mapping normalized device coordinates to framebuffer coordinates: X and Y in pixels
+// and Z in the range [MinDepth..MaxDepth]. To transform clip space coordinates, multiply by this matrix and then
+// homogenize, or homogenize first; the result is the same.
+func (vp Viewport) NDCToWindow() Mat {
+	return Mat{
+		{vp.Width / 2, 0, 0, 0},
+		{0, vp.Height / 2, 0, 0},
+		{0, 0, vp.MaxDepth - vp.MinDepth, 0},
+		{vp.X + vp.Width/2, vp.Y + vp.Height/2, vp.MinDepth, 1},
+	}
+}
+
+// WindowToNDC returns the matrix mapping framebuffer coordinates back to normalized device coordinates, the inverse of
+// [Viewport.NDCToWindow]. If MinDepth equals MaxDepth, depth cannot be recovered and the Z component is undefined.
+func (vp Viewport) WindowToNDC() Mat {
+	dz := vp.MaxDepth - vp.MinDepth
+	return Mat{
+		{2 / vp.Width, 0, 0, 0},
+		{0, 2 / vp.Height, 0, 0},
+		{0, 0, 1 / dz, 0},
+		{-(2*vp.X + vp.Width) / vp.Width, -(2*vp.Y + vp.Height) / vp.Height, -vp.MinDepth / dz, 1},
+	}
+}
+
+// Project transforms the world-space point obj by the view and projection matricies and maps the result to
+// framebuffer coordinates within vp. The X and Y components of the result are in pixels and the Z component is the
+// depth, in the range [MinDepth..MaxDepth].
+func (vp Viewport) Project(obj Pt, view, proj Mat) Pt3 {
+	w := vp.NDCToWindow().MultM(proj).MultM(view).MultP(obj).Homogenize()
+	return Pt3{w[0], w[1], w[2]}
+}
+
+// Unproject is the inverse of [Viewport.Project], mapping a framebuffer coordinate and depth back into world space. If
+// the depth corresponds to a far plane at infinity, the result will have infinite components.
+func (vp Viewport) Unproject(win Pt3, view, proj Mat) Pt {
+	return proj.MultM(view).Inverse().MultP(vp.WindowToNDC().MultP(win.Homogenize())).Homogenize()
+}
+
+// ScreenPointToRay returns a world-space ray from the near plane through the provided framebuffer coordinate, for
+// example to pick the object under the mouse cursor. view and proj must target Vulkan, as the package-level projection
+// functions do; for other clip spaces, use [ClipSpace.ScreenPointToRay]. Both standard and reversed depth projections
+// (see [InvertedDepthPerspective]) are supported, as are orthographic projections.
+func (vp Viewport) ScreenPointToRay(screen Pt2, view, proj Mat) Ray {
+	return ClipSpaceVulkan.ScreenPointToRay(vp, screen, view, proj)
+}
+
+// ScreenPointToRay returns a world-space ray from the near plane through the provided framebuffer coordinate within vp,
+// where view and proj were built for cs. This is the equivalent of [Viewport.ScreenPointToRay] for any API: the ray is
+// found by unprojecting depths inside cs's normalized device depth range, and view space handedness decides which end
+// of that range is nearer to the camera.
+func (cs ClipSpace) ScreenPointToRay(vp Viewport, screen Pt2, view, proj Mat) Ray {
+	inv := proj.MultM(view).Inverse()
+	ndc := vp.WindowToNDC().MultP(Pt{screen[0], screen[1], vp.MinDepth, 1})
+	at := func(depth float32) Pt {
+		return inv.MultP(Pt{ndc[0], ndc[1], depth, 1}).Homogenize()
+	}
+
+	lo, hi := float32(0), float32(1)
+	if cs.DepthNegativeOneToOne {
+		lo = -1
+	}
+
+	// Sample two depths that are finite even if the far plane is at infinity, and use the view space z coordinate to
+	// decide which depth is nearer to the camera. z decreases into the screen, unless the view is left-handed.
+	a, b := at(lo+(hi-lo)/4), at(lo+3*(hi-lo)/4)
+	za, zb := view.MultP(a)[2], view.MultP(b)[2]
+	if cs.LeftHanded {
+		za, zb = -za, -zb
+	}
+	nearDepth, far := lo, b
+	if za < zb {
+		nearDepth, far = hi, a
+	}
+
+	origin := at(nearDepth)
+	return NewRay(origin, origin.VecTo(far))
+}
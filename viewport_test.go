@@ -0,0 +1,97 @@
+package vkm
+
+import "testing"
+
+func TestViewportMatricies(t *testing.T) {
+	vp := Viewport{10, 20, 800, 600, 0.25, 0.75}
+	m := vp.NDCToWindow()
+
+	cases := []struct {
+		ndc, win Pt
+	}{
+		{NewPt(-1, -1, 0), NewPt(10, 20, 0.25)},  // top left, near
+		{NewPt(1, 1, 1), NewPt(810, 620, 0.75)},  // bottom right, far
+		{NewPt(0, 0, 0.5), NewPt(410, 320, 0.5)}, // center
+	}
+	for _, c := range cases {
+		if res := m.MultP(c.ndc); !res.EqualTo(c.win) {
+			t.Errorf("NDCToWindow mapped %+v incorrectly! Expected: %+v Actual: %+v", c.ndc, c.win, res)
+		}
+		if res := vp.WindowToNDC().MultP(c.win); !res.EqualTo(c.ndc) {
+			t.Errorf("WindowToNDC mapped %+v incorrectly! Expected: %+v Actual: %+v", c.win, c.ndc, res)
+		}
+	}
+}
+
+func TestViewportNegativeHeight(t *testing.T) {
+	// The negative height trick: Y is the bottom of the framebuffer, and NDC +Y points up the screen
+	vp := Viewport{0, 600, 800, -600, 0, 1}
+
+	if res := vp.NDCToWindow().MultP(NewPt(0, 1, 0)); !res.EqualTo(NewPt(400, 0, 0)) {
+		t.Errorf("NDC +Y did not map to the top of the framebuffer! Actual: %+v", res)
+	}
+	if res := vp.NDCToWindow().MultP(NewPt(-1, -1, 0)); !res.EqualTo(NewPt(0, 600, 0)) {
+		t.Errorf("NDC (-1, -1) did not map to the bottom left of the framebuffer! Actual: %+v", res)
+	}
+
+	// A Y-up projection, as used with a negative height viewport
+	view := ClipSpaceOpenGL.LookAt(NewPt(0, 0, 10), Origin(), NewVec(0, 1, 0))
+	proj := ClipSpace{}.PerspectiveDeg(90, 800.0/600.0, 1, 100)
+	above := vp.Project(NewPt(0, 1, 0), view, proj)
+	if above[1] >= 300 {
+		t.Errorf("World +Y did not project to the top half of the framebuffer! Actual: %+v", above)
+	}
+
+	target := NewPt(1, 2, -3)
+	win := vp.Project(target, view, proj)
+	if res := vp.Unproject(win, view, proj); !testApproxPt(res, target, 0.001) {
+		t.Errorf("Unproject did not invert Project! Expected: %+v Actual: %+v", target, res)
+	}
+
+	ray := vp.ScreenPointToRay(Pt2{win[0], win[1]}, view, proj)
+	dist := ray.Origin.VecTo(target).Length()
+	if res := ray.At(dist); !testApproxPt(res, target, 0.001) {
+		t.Errorf("Ray does not pass through the picked point! Expected: %+v Actual: %+v", target, res)
+	}
+}
+
+func TestClipSpaceScreenPointToRay(t *testing.T) {
+	vp := NewViewport(0, 0, 800, 600)
+	eye := NewPt(0, 0, 10)
+	target := NewPt(1, 2, -3)
+
+	for name, cs := range map[string]ClipSpace{
+		"Vulkan":    ClipSpaceVulkan,
+		"OpenGL":    ClipSpaceOpenGL,
+		"Direct3D":  ClipSpaceDirect3D,
+		"zero":      {},
+		"OpenGL LH": {DepthNegativeOneToOne: true, LeftHanded: true},
+	} {
+		view := cs.LookAt(eye, Origin(), UnitVecY())
+		proj := cs.PerspectiveDeg(90, 800.0/600.0, 1, 100)
+
+		// The center of the screen looks straight at the origin, starting on the near plane
+		ray := cs.ScreenPointToRay(vp, Pt2{400, 300}, view, proj)
+		if exp := NewPt(0, 0, 9); !testApproxPt(ray.Origin, exp, 0.001) {
+			t.Errorf("%s: Ray did not start on the near plane! Expected: %+v Actual: %+v", name, exp, ray.Origin)
+		}
+		if exp := Pt(UnitVecZ().Invert()); !testApproxPt(Pt(ray.Direction), exp, 0.001) {
+			t.Errorf("%s: Ray did not point into the screen! Expected: %+v Actual: %+v", name, exp, ray.Direction)
+		}
+
+		// Only X and Y of the projected point are used, so the viewport's depth mapping does not matter here
+		win := vp.Project(target, view, proj)
+		ray = cs.ScreenPointToRay(vp, Pt2{win[0], win[1]}, view, proj)
+		dist := ray.Origin.VecTo(target).Length()
+		if res := ray.At(dist); !testApproxPt(res, target, 0.001) {
+			t.Errorf("%s: Ray does not pass through the picked point! Expected: %+v Actual: %+v", name, target, res)
+		}
+	}
+
+	// The package-level functions target Vulkan
+	view := LookAt(eye, Origin(), UnitVecY())
+	proj := PerspectiveDeg(90, 800.0/600.0, 1, 100)
+	if exp, res := ClipSpaceVulkan.ScreenPointToRay(vp, Pt2{100, 50}, view, proj), vp.ScreenPointToRay(Pt2{100, 50}, view, proj); !testApproxPt(res.Origin, exp.Origin, 0.00001) {
+		t.Errorf("Viewport.ScreenPointToRay did not match Vulkan! Expected: %+v Actual: %+v", exp, res)
+	}
+}